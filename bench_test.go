@@ -357,6 +357,33 @@ func BenchmarkFloat64Rand(b *testing.B) {
 	fsink = y
 }
 
+func BenchmarkNormFloat64(b *testing.B) {
+	var y float64
+	x := NewXoro(1)
+	// x := NewXosh(1)
+	for n := 0; n < b.N; n++ {
+		y = x.NormFloat64()
+	}
+	fsink = y
+}
+func BenchmarkExpFloat64(b *testing.B) {
+	var y float64
+	x := NewXoro(1)
+	for n := 0; n < b.N; n++ {
+		y = x.ExpFloat64()
+		// y = x.ExpFloat64full()
+	}
+	fsink = y
+}
+func BenchmarkNormFloat64Rand(b *testing.B) {
+	var y float64
+	x := rand.New(rand.NewSource(1))
+	for n := 0; n < b.N; n++ {
+		y = x.NormFloat64()
+	}
+	fsink = y
+}
+
 //----------------------------------Uint64----------------Uint64------//
 func BenchmarkSplitmix(b *testing.B) {
 	var y uint64
@@ -391,6 +418,91 @@ func Benchmark128starstar(b *testing.B) {
 	}
 	usink = y
 }
+
+// Prng, XoshPrng and MCGPrng Uint64 are inlined and as fast as Uint64 of
+// their generators. genericPrng is a Prng[E] with the generator as a type
+// parameter for comparison. Its calls to the generator go through the type
+// parameter dictionary, are not inlined and the Prng escapes to heap.
+type genericPrng[E any, P interface {
+	*E
+	Seed(uint64)
+	Uint64() uint64
+}] struct {
+	rng E
+}
+
+func (r *genericPrng[E, P]) Uint64() uint64 {
+	return P(&r.rng).Uint64()
+}
+
+func BenchmarkPrngUint64(b *testing.B) {
+	var y uint64
+	x := New(1)
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64()
+	}
+	usink = y
+}
+func BenchmarkGenericPrngUint64(b *testing.B) {
+	var y uint64
+	var x genericPrng[Xoro, *Xoro]
+	x.rng.Seed(1)
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64()
+	}
+	usink = y
+}
+func BenchmarkXoshPrngUint64(b *testing.B) {
+	var y uint64
+	x := NewXoshPrng(1)
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64()
+	}
+	usink = y
+}
+func BenchmarkGenericXoshPrngUint64(b *testing.B) {
+	var y uint64
+	var x genericPrng[Xosh, *Xosh]
+	x.rng.Seed(1)
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64()
+	}
+	usink = y
+}
+func BenchmarkMCGPrngUint64(b *testing.B) {
+	var y uint64
+	x := NewMCGPrng(1)
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64()
+	}
+	usink = y
+}
+func BenchmarkGenericMCGPrngUint64(b *testing.B) {
+	var y uint64
+	var x genericPrng[MCG, *MCG]
+	x.rng.Seed(1)
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64()
+	}
+	usink = y
+}
+func BenchmarkMCGUint64(b *testing.B) {
+	var y uint64
+	x := NewMCG(1)
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64()
+	}
+	usink = y
+}
+func BenchmarkUint64n(b *testing.B) {
+	var y uint64
+	x := New(1)
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64n(1<<63 + 1)
+		// y = x.Uint64nBiased(1<<63 + 1)
+	}
+	usink = y
+}
 func Benchmark128starstarGlobalRand(b *testing.B) {
 	var y uint64
 	for n := 0; n < b.N; n++ {
@@ -433,6 +545,14 @@ func Benchmark128sourceInterface(b *testing.B) {
 	}
 	usink = y
 }
+func Benchmark128sourceMathRand(b *testing.B) {
+	var y uint64
+	x := rand.New(NewXoroSource(1))
+	for n := 0; n < b.N; n++ {
+		y = x.Uint64()
+	}
+	usink = y
+}
 func Benchmark256starstar(b *testing.B) {
 	var y uint64
 	x := NewXosh(1)
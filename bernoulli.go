@@ -0,0 +1,108 @@
+package prng
+
+import (
+	"math"
+	"math/bits"
+)
+
+// A bitBuffer holds the random bits left over by Bernoulli, n of them from
+// the top of bits. They are not part of the generator state.
+type bitBuffer struct {
+	bits uint64
+	n    uint
+}
+
+// expansion returns the binary expansion of p in (0, 1) as the number of
+// zeros after the point and the nm bits of m = p 2^s, m odd, top aligned.
+func expansion(p float64) (zeros, nm uint, m uint64) {
+	u := math.Float64bits(p)
+	exp := u >> 52
+	m = u & (1<<52 - 1)
+	s := uint(1074)
+	if exp != 0 {
+		m |= 1 << 52
+		s = uint(1075 - exp)
+	}
+	tz := uint(bits.TrailingZeros64(m))
+	m >>= tz
+	s -= tz
+	nm = uint(bits.Len64(m))
+	return s - nm, nm, m << (64 - nm)
+}
+
+// consume drops k <= b.n bits from the top of b.bits.
+func (b *bitBuffer) consume(k uint) {
+	b.bits <<= k
+	b.n -= k
+}
+
+// Bernoulli returns true with probability p exactly, for any float64 p in [0, 1],
+// also for the subnormal floats. It panics if p is not in [0, 1].
+//
+// The bits of a uniform U in [0, 1) are compared lazily to the binary expansion
+// of p until the first difference, which decides U < p. This takes 2 bits on
+// average. The bits left over from a Uint64 are kept in r for the next call,
+// and Seed, Jump and ReadState drop them.
+// Float64() < p is quantized to 2^-53 and uses 64 bits for each decision.
+func (r *Prng) Bernoulli(p float64) bool {
+	if !(p >= 0 && p <= 1) {
+		panic("invalid argument to Bernoulli")
+	}
+	if p == 0 || p == 1 {
+		return p == 1
+	}
+	b := &r.buf
+	if b.n == 0 {
+		b.bits, b.n = r.rng.Uint64(), 64
+	}
+	zeros, nm, m := expansion(p)
+	if zeros < 64 {
+		// Most calls are decided by the bits in b and the first 64 bits e
+		// of the expansion.
+		e := m >> zeros
+		n := min(zeros + nm, b.n)
+		if k := uint(bits.LeadingZeros64(b.bits ^ e)); k < n {
+			b.consume(k + 1)
+			return e << k >> 63 == 1
+		}
+		if n == zeros + nm {
+			b.consume(n)
+			return false
+		}
+	}
+	for zeros > 0 {
+		if b.n == 0 {
+			b.bits, b.n = r.rng.Uint64(), 64
+		}
+		n := min(zeros, b.n)
+		if b.bits >> (64 - n) != 0 {
+			// A 1 bit in U before the first 1 bit of p.
+			b.consume(uint(bits.LeadingZeros64(b.bits)) + 1)
+			return false
+		}
+		b.consume(n)
+		zeros -= n
+	}
+	for nm > 0 {
+		if b.n == 0 {
+			b.bits, b.n = r.rng.Uint64(), 64
+		}
+		n := min(nm, b.n)
+		if d := (b.bits ^ m) >> (64 - n); d != 0 {
+			k := uint(bits.LeadingZeros64(b.bits ^ m))
+			b.consume(k + 1)
+			return m << k >> 63 == 1 // U has 0 and p 1
+		}
+		b.consume(n)
+		m <<= n
+		nm -= n
+	}
+	// U equals p up to the last 1 bit of p, so U >= p.
+	return false
+}
+
+// Bernoulli returns true with probability p exactly, for any float64 p in [0, 1].
+// It panics if p is not in [0, 1].
+func Bernoulli(p float64) bool {
+	return globalPrng.Bernoulli(p)
+}
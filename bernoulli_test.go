@@ -0,0 +1,95 @@
+package prng
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// bitReader reads the bits of a generator one at a time from the top.
+type bitReader struct {
+	rng   Xoro
+	word  uint64
+	nbits int
+}
+
+func (b *bitReader) bit() uint {
+	if b.nbits == 0 {
+		b.word, b.nbits = b.rng.Uint64(), 64
+	}
+	b.nbits--
+	return uint(b.word >> b.nbits & 1)
+}
+
+// bernoulliRef compares the bits of U one at a time to the expansion of p
+// as an exact integer p 2^1074.
+func bernoulliRef(b *bitReader, p float64) bool {
+	if p == 0 || p == 1 {
+		return p == 1
+	}
+	mant, _ := new(big.Float).SetMantExp(big.NewFloat(p), 1074).Int(nil)
+	last := 1074 - int(mant.TrailingZeroBits())
+	for i := 1; i <= last; i++ {
+		pb, ub := mant.Bit(1074 - i), b.bit()
+		if ub != pb {
+			return ub < pb
+		}
+	}
+	return false
+}
+
+func TestBernoulliReference(t *testing.T) {
+	r := New(1)
+	ref := bitReader{rng: r.rng}
+	ps := []float64{
+		0.5, 0.25, 0.75, 1.0 / 3, 0.1, 0.999, math.Nextafter(1, 0), 1, 0,
+		0x1p-60, 0x1p-1000, 3 * 0x1p-1000, 5e-324, 1e-310, math.SmallestNonzeroFloat64 * 12345,
+	}
+	x := NewXosh(2)
+	for i := range 200000 {
+		p := ps[i % len(ps)]
+		if i % 3 == 0 {
+			p = x.Float64()
+		}
+		if got, want := r.Bernoulli(p), bernoulliRef(&ref, p); got != want {
+			t.Fatalf("call %d: Bernoulli(%g) = %v, reference %v", i, p, got, want)
+		}
+	}
+	if r.rng != ref.rng || r.buf.n != uint(ref.nbits) {
+		t.Errorf("Bernoulli used different bits than the reference")
+	}
+}
+
+func TestBernoulli(t *testing.T) {
+	const rounds = 1000000
+	for _, p := range []float64{0.5, 0.3, 1.0 / 3, 1e-3, 0.999999} {
+		r := New(1)
+		start := r.rng
+		n := 0.0
+		for range rounds {
+			if r.Bernoulli(p) {
+				n++
+			}
+		}
+		if z := (n - rounds * p) / math.Sqrt(rounds * p * (1 - p)); abs(z) > 5 {
+			t.Errorf("Bernoulli(%g): %0.f of %d, z = %f", p, n, rounds, z)
+		}
+		words := 0
+		for ; start != r.rng; words++ {
+			start.Uint64()
+		}
+		if b := float64(words * 64 - int(r.buf.n)) / rounds; b > 2.01 {
+			t.Errorf("Bernoulli(%g): %f bits per call", p, b)
+		}
+	}
+	for _, p := range []float64{-1, math.NaN(), 1.5} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Bernoulli(%g) did not panic", p)
+				}
+			}()
+			Bernoulli(p)
+		}()
+	}
+}
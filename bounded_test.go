@@ -0,0 +1,96 @@
+package prng
+
+import (
+	"strings"
+	"testing"
+)
+
+// With n = 2^64 * 2/3 the numbers in [0, n/2) have double
+// probability 2/3 in modulo reduction instead of 1/2.
+const adversarialN = 0xAAAAAAAAAAAAAAAB
+
+func lowHalfRatio(f func(uint64) uint64, n uint64, rounds int) float64 {
+	low := 0
+	for i := 0; i < rounds; i++ {
+		u := f(n)
+		if u >= n {
+			return -1
+		}
+		if u < n/2 {
+			low++
+		}
+	}
+	return float64(low) / float64(rounds)
+}
+
+func TestUint64nUnbiased(t *testing.T) {
+	const rounds = 1000000
+	const failLim = 5e-3
+	xoro, xosh, mcg, r := NewXoro(1), NewXosh(1), NewMCG(1), New(1)
+	funcs := map[string]func(uint64) uint64{
+		"Xoro": xoro.Uint64n,
+		"Xosh": xosh.Uint64n,
+		"MCG":  mcg.Uint64n,
+		"Prng": r.Uint64n,
+		"Int63n": func(n uint64) uint64 {
+			return uint64(r.Int63n(int64(n)))
+		},
+	}
+	for name, f := range funcs {
+		for _, n := range []uint64{adversarialN, 1<<63 + 1, 1<<64 - 1, 3, 1 << 40} {
+			if name == "Int63n" && n >= 1<<63 {
+				n >>= 1
+			}
+			ratio := lowHalfRatio(f, n, rounds)
+			expected := float64(n/2) / float64(n)
+			t.Logf("%-6s n=%X low half ratio %f", name, n, ratio)
+			if abs(ratio-expected) > failLim {
+				t.Errorf("%s: n=%X low half ratio %f", name, n, ratio)
+			}
+		}
+	}
+	ratio := lowHalfRatio(r.Uint64nBiased, adversarialN, rounds)
+	t.Logf("Biased n=%X low half ratio %f", uint64(adversarialN), ratio)
+	if abs(ratio-2.0/3) > failLim {
+		t.Errorf("Uint64nBiased: low half ratio %f, expected 2/3", ratio)
+	}
+}
+
+func TestUint64nTabUnbiased(t *testing.T) {
+	const rounds = 10000000
+	const cells = 1000
+	const failLim = 5e-2
+	x := NewXosh(1)
+	var tab [cells]int
+	for i := 0; i < rounds; i++ {
+		tab[x.Uint64n(cells)]++
+	}
+	expected := rounds / cells
+	for i := 0; i < cells; i++ {
+		reldiff := float64(tab[i]-expected) / float64(expected)
+		if abs(reldiff) > failLim {
+			t.Errorf("%d %d %4.2e", i, tab[i], reldiff)
+		}
+	}
+}
+
+func TestBiasedPanics(t *testing.T) {
+	r, s := New(1), NewXoshPrng(1)
+	for name, f := range map[string]func(){
+		"Uint64nBiased":          func() { Uint64nBiased(0) },
+		"Int63nBiased":           func() { Int63nBiased(0) },
+		"IntnBiased":             func() { IntnBiased(-1) },
+		"Prng.Uint64nBiased":     func() { r.Uint64nBiased(0) },
+		"XoshPrng.Uint64nBiased": func() { s.Uint64nBiased(0) },
+	} {
+		func() {
+			defer func() {
+				want := "invalid argument to " + name[strings.LastIndex(name, ".") + 1:]
+				if msg := recover(); msg != want {
+					t.Errorf("%s: panic %v, want %q", name, msg, want)
+				}
+			}()
+			f()
+		}()
+	}
+}
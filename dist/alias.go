@@ -0,0 +1,268 @@
+package dist
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"sort"
+)
+
+// An AliasTable samples a categorical distribution of n categories in O(1)
+// time from a single Uint64 by Walker's alias method with Vose's construction.
+//
+// The table has N = 2^b >= n columns. The high b bits of a Uint64 select
+// the column and the next 63-b bits are compared to the integer threshold of
+// the column. Below the threshold the column's own category is returned,
+// otherwise the alias of the column. All the probabilities are integer
+// multiples of 2^-63 and the column selection is exact. The table is built
+// by exact integer arithmetic from the float64 weights: the probability of
+// category i is w_i / Σw rounded to a multiple of 2^-63 by largest remainders,
+// so the error of each probability is less than 2^-63.
+type AliasTable struct {
+	n     int
+	b     uint       // log2 of the number of columns
+	shift uint       // 64 - b
+	mask  uint64     // 2^(63-b) - 1
+	col   []aliasCol
+}
+
+type aliasCol struct {
+	thresh uint64 // in [0, 2^(63-b)]
+	alias  uint32
+}
+
+// maxAliasCategories is the maximum number of categories of an AliasTable.
+const maxAliasCategories = 1 << 31
+
+// NewAliasTable returns an AliasTable of the weights. The weights must be
+// non-negative and finite and at least one weight must be positive.
+func NewAliasTable(weights []float64) (*AliasTable, error) {
+	n := len(weights)
+	if n == 0 || n > maxAliasCategories {
+		return nil, paramError("AliasTable", "categories", float64(n))
+	}
+	for _, w := range weights {
+		if !(w >= 0 && w <= math.MaxFloat64) {
+			return nil, paramError("AliasTable", "weight", w)
+		}
+	}
+	units, ok := aliasUnits(weights)
+	if !ok {
+		return nil, paramError("AliasTable", "weight sum", 0)
+	}
+	b := aliasBits(n)
+	t := &AliasTable{
+		n:     n,
+		b:     b,
+		shift: 64 - b,
+		mask:  1<<(63-b) - 1,
+		col:   make([]aliasCol, 1<<b),
+	}
+	t.build(units)
+	return t, nil
+}
+
+// aliasBits returns log2 of the number of columns for n categories.
+func aliasBits(n int) uint {
+	return max(1, uint(bits.Len(uint(n - 1))))
+}
+
+// aliasUnits returns the probabilities of the weights as integers
+// summing to 2^63. It returns false if all the weights are zero.
+func aliasUnits(weights []float64) ([]uint64, bool) {
+	// A weight w = m * 2^e exactly with an integer m.
+	emin := math.MaxInt
+	for _, w := range weights {
+		if w > 0 {
+			_, e := math.Frexp(w)
+			emin = min(emin, e - 53)
+		}
+	}
+	if emin == math.MaxInt {
+		return nil, false
+	}
+	scaled := make([]*big.Int, len(weights))
+	sum := new(big.Int)
+	for i, w := range weights {
+		frac, e := math.Frexp(w)
+		m := new(big.Int).SetUint64(uint64(math.Ldexp(frac, 53)))
+		scaled[i] = m.Lsh(m, uint(e - 53 - emin))
+		sum.Add(sum, scaled[i])
+	}
+	units := make([]uint64, len(weights))
+	rems := make([]*big.Int, len(weights))
+	left := uint64(1) << 63
+	for i, m := range scaled {
+		q, r := new(big.Int), new(big.Int)
+		q.QuoRem(m.Lsh(m, 63), sum, r)
+		units[i] = q.Uint64()
+		rems[i] = r
+		left -= units[i]
+	}
+	// The remaining units, fewer than n, go to the largest remainders.
+	idx := make([]int, len(weights))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		return rems[idx[a]].Cmp(rems[idx[b]]) > 0
+	})
+	for i := uint64(0); i < left; i++ {
+		units[idx[i]]++
+	}
+	return units, true
+}
+
+// build fills the columns by Vose's method in integer arithmetic.
+// A column has capacity 2^(63-b) units.
+func (t *AliasTable) build(units []uint64) {
+	capacity := uint64(1) << (63 - t.b)
+	m := make([]uint64, len(t.col))
+	copy(m, units)
+	var small, large []uint32
+	for i := range m {
+		if m[i] < capacity {
+			small = append(small, uint32(i))
+		} else {
+			large = append(large, uint32(i))
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		t.col[s] = aliasCol{thresh: m[s], alias: l}
+		m[l] -= capacity - m[s]
+		if m[l] < capacity {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// The sums are exact and the rest have full columns.
+	for _, i := range append(small, large...) {
+		t.col[i] = aliasCol{thresh: capacity, alias: i}
+	}
+}
+
+// Len returns the number of categories of t.
+func (t *AliasTable) Len() int { return t.n }
+
+// Index returns the category in [0, t.Len()) selected by the uniform u,
+// eg. t.Index(x.Uint64()) with any generator x.
+func (t *AliasTable) Index(u uint64) int {
+	c := &t.col[u >> t.shift]
+	if u & t.mask < c.thresh {
+		return int(u >> t.shift)
+	}
+	return int(c.alias)
+}
+
+// Prob returns the probability of the category i in t.
+func (t *AliasTable) Prob(i int) float64 {
+	return float64(t.units(i)) * 0x1p-63
+}
+
+// units returns the probability of the category i in units of 2^-63.
+func (t *AliasTable) units(i int) uint64 {
+	capacity := uint64(1) << (63 - t.b)
+	var u uint64
+	for c, col := range t.col {
+		if c == i {
+			u += col.thresh
+		}
+		if int(col.alias) == i {
+			u += capacity - col.thresh
+		}
+	}
+	return u
+}
+
+// The binary format of an AliasTable, all integers big-endian:
+//   4 bytes   "ALS1"
+//   4 bytes   number of categories n
+//   1 byte    b, log2 of the number of columns
+//   2^b x 12  column threshold uint64 and alias uint32
+const aliasMagic = "ALS1"
+
+// ErrAliasFormat is returned by UnmarshalBinary for invalid data.
+var ErrAliasFormat = errors.New("dist: invalid AliasTable data")
+
+// MarshalBinary returns the table in a binary format for caching.
+func (t *AliasTable) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 9 + 12 * len(t.col))
+	data = append(data, aliasMagic...)
+	data = binary.BigEndian.AppendUint32(data, uint32(t.n))
+	data = append(data, byte(t.b))
+	for _, c := range t.col {
+		data = binary.BigEndian.AppendUint64(data, c.thresh)
+		data = binary.BigEndian.AppendUint32(data, c.alias)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary sets t from data written by MarshalBinary.
+func (t *AliasTable) UnmarshalBinary(data []byte) error {
+	if len(data) < 9 || string(data[:4]) != aliasMagic {
+		return ErrAliasFormat
+	}
+	n := int(binary.BigEndian.Uint32(data[4:]))
+	b := uint(data[8])
+	if n == 0 || n > maxAliasCategories || b != aliasBits(n) {
+		return ErrAliasFormat
+	}
+	data = data[9:]
+	if len(data) != 12 << b {
+		return ErrAliasFormat
+	}
+	capacity := uint64(1) << (63 - b)
+	col := make([]aliasCol, 1<<b)
+	for i := range col {
+		col[i].thresh = binary.BigEndian.Uint64(data[12*i:])
+		col[i].alias = binary.BigEndian.Uint32(data[12*i+8:])
+		if col[i].thresh > capacity || int(col[i].alias) >= n ||
+			i >= n && col[i].thresh != 0 {
+			return ErrAliasFormat
+		}
+	}
+	*t = AliasTable{n: n, b: b, shift: 64 - b, mask: capacity - 1, col: col}
+	return nil
+}
+
+// An Alias is a categorical distribution sampled by an AliasTable.
+type Alias[S Source] struct {
+	src   S
+	table *AliasTable
+}
+
+// NewAlias returns a categorical distribution of the weights using the
+// generator src. See NewAliasTable for the weights.
+func NewAlias[S Source](weights []float64, src S) (*Alias[S], error) {
+	t, err := NewAliasTable(weights)
+	if err != nil {
+		return nil, err
+	}
+	return &Alias[S]{src: src, table: t}, nil
+}
+
+// NewAliasOf returns a categorical distribution of the table t using the
+// generator src.
+func NewAliasOf[S Source](t *AliasTable, src S) *Alias[S] {
+	return &Alias[S]{src: src, table: t}
+}
+
+// Table returns the AliasTable of a.
+func (a *Alias[S]) Table() *AliasTable { return a.table }
+
+// Rand returns a pseudo-random category by a single Uint64.
+func (a *Alias[S]) Rand() int {
+	return a.table.Index(a.src.Uint64())
+}
+
+// Fill fills dst with pseudo-random categories.
+func (a *Alias[S]) Fill(dst []int) {
+	for i := range dst {
+		dst[i] = a.table.Index(a.src.Uint64())
+	}
+}
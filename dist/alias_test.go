@@ -0,0 +1,135 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+// exactUnits returns w_i / Σw * 2^63 as exact rationals.
+func exactUnits(weights []float64) []*big.Rat {
+	sum := new(big.Rat)
+	for _, w := range weights {
+		sum.Add(sum, new(big.Rat).SetFloat64(w))
+	}
+	scale := new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), 63))
+	u := make([]*big.Rat, len(weights))
+	for i, w := range weights {
+		u[i] = new(big.Rat).SetFloat64(w)
+		u[i].Quo(u[i], sum).Mul(u[i], scale)
+	}
+	return u
+}
+
+func TestAliasTableExact(t *testing.T) {
+	for _, weights := range [][]float64{
+		{1},
+		{0, 1},
+		{1, 1, 1},
+		{0.1, 0.2, 0.3, 0.4},
+		{1e-300, 1e300, 5e-324, 1},
+		{math.MaxFloat64, math.MaxFloat64, 1},
+		{3, 0, 0, 7, 0.5, 1.0 / 3, math.Pi, 0},
+	} {
+		tab, err := NewAliasTable(weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exact := exactUnits(weights)
+		total := uint64(0)
+		one := big.NewRat(1, 1)
+		for i := range weights {
+			u := tab.units(i)
+			total += u
+			d := new(big.Rat).Sub(new(big.Rat).SetUint64(u), exact[i])
+			if d.Abs(d).Cmp(one) >= 0 {
+				t.Errorf("%v: category %d has %d units, want %s", weights, i, u, exact[i].FloatString(2))
+			}
+			if weights[i] == 0 && u != 0 {
+				t.Errorf("%v: zero weight %d has %d units", weights, i, u)
+			}
+		}
+		if total != 1<<63 {
+			t.Errorf("%v: total units %d", weights, total)
+		}
+	}
+}
+
+func TestAliasTableIndex(t *testing.T) {
+	tab, _ := NewAliasTable([]float64{0, 1, 0})
+	for _, u := range []uint64{0, 1, 1 << 63, math.MaxUint64, 0x5555555555555555} {
+		if i := tab.Index(u); i != 1 {
+			t.Errorf("Index(%#x) = %d", u, i)
+		}
+	}
+}
+
+func TestAlias(t *testing.T) {
+	const rounds = 1000000
+	weights := []float64{5, 0, 1, 2.5, 0.01, 9, 3, 0.3, 1e-3, 4, 4}
+	pmf := make([]float64, len(weights))
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+	for i, w := range weights {
+		pmf[i] = w / sum
+	}
+	sample := make([]uint64, rounds)
+	chi := func(name string, rand func() int) {
+		for i := range sample {
+			sample[i] = uint64(rand())
+		}
+		chiSquare(t, name, sample, 0, pmf)
+	}
+	r, xoro, xosh, mcg := prng.New(1), prng.NewXoro(2), prng.NewXosh(3), prng.NewMCG(4)
+	a, err := NewAlias(weights, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chi("Alias Prng", a.Rand)
+	chi("Alias Xoro", NewAliasOf(a.Table(), &xoro).Rand)
+	chi("Alias Xosh", NewAliasOf(a.Table(), &xosh).Rand)
+	chi("Alias MCG", NewAliasOf(a.Table(), &mcg).Rand)
+}
+
+func TestAliasTableBinary(t *testing.T) {
+	weights := []float64{2, 0, 1, 7, 0.25}
+	tab, _ := NewAliasTable(weights)
+	data, err := tab.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got AliasTable
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if got.Len() != tab.Len() {
+		t.Fatalf("Len %d, want %d", got.Len(), tab.Len())
+	}
+	x := prng.NewXoro(1)
+	for range 10000 {
+		u := x.Uint64()
+		if got.Index(u) != tab.Index(u) {
+			t.Fatalf("Index(%#x) differs after unmarshal", u)
+		}
+	}
+	for _, bad := range [][]byte{
+		nil,
+		data[:len(data)-1],
+		append([]byte("ALS2"), data[4:]...),
+		append(append([]byte{}, data[:8]...), append([]byte{4}, data[9:]...)...),
+	} {
+		if err := got.UnmarshalBinary(bad); !errors.Is(err, ErrAliasFormat) {
+			t.Errorf("UnmarshalBinary of bad data: %v", err)
+		}
+	}
+	for _, w := range [][]float64{nil, {0, 0}, {1, -1}, {math.Inf(1)}, {math.NaN()}} {
+		if _, err := NewAliasTable(w); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewAliasTable(%v): %v", w, err)
+		}
+	}
+}
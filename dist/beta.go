@@ -0,0 +1,130 @@
+package dist
+
+import (
+	"math"
+)
+
+// A Beta is a beta distribution with shapes α and β on [0, 1],
+// density x^(α-1) (1-x)^(β-1) / B(α, β).
+type Beta[S Source] struct {
+	x, y  Gamma[S]
+	alpha float64
+	beta  float64
+}
+
+// NewBeta returns a beta distribution with shapes alpha and beta
+// using the generator src. alpha and beta must be positive.
+func NewBeta[S Source](alpha, beta float64, src S) (*Beta[S], error) {
+	if !positive(alpha) {
+		return nil, paramError("Beta", "alpha", alpha)
+	}
+	if !positive(beta) {
+		return nil, paramError("Beta", "beta", beta)
+	}
+	b := &Beta[S]{alpha: alpha, beta: beta}
+	b.x.init(alpha, 1, src)
+	b.y.init(beta, 1, src)
+	return b, nil
+}
+
+// Alpha returns the shape α of b.
+func (b *Beta[S]) Alpha() float64 { return b.alpha }
+
+// Beta returns the shape β of b.
+func (b *Beta[S]) Beta() float64 { return b.beta }
+
+// Rand returns a beta distributed pseudo-random float64 as X / (X + Y),
+// where X and Y are gamma variates with shapes α and β.
+// With small shapes both X and Y may underflow to 0 and the pair is rejected.
+func (b *Beta[S]) Rand() float64 {
+	for {
+		x := b.x.Rand()
+		y := b.y.Rand()
+		if s := x + y; s > 0 {
+			return x / s
+		}
+	}
+}
+
+// Fill fills dst with beta distributed pseudo-random float64s.
+func (b *Beta[S]) Fill(dst []float64) {
+	for i := range dst {
+		dst[i] = b.Rand()
+	}
+}
+
+// A StudentsT is a Student's t-distribution with ν degrees of freedom.
+type StudentsT[S Source] struct {
+	c  ChiSquared[S]
+	nu float64
+}
+
+// NewStudentsT returns a Student's t-distribution with nu degrees of freedom
+// using the generator src. nu must be positive.
+func NewStudentsT[S Source](nu float64, src S) (*StudentsT[S], error) {
+	if !positive(nu) {
+		return nil, paramError("StudentsT", "nu", nu)
+	}
+	t := &StudentsT[S]{nu: nu}
+	t.c.k = nu
+	t.c.g.init(nu / 2, 2, src)
+	return t, nil
+}
+
+// Nu returns the degrees of freedom of t.
+func (t *StudentsT[S]) Nu() float64 { return t.nu }
+
+// Rand returns a t-distributed pseudo-random float64 as Z / sqrt(V/ν),
+// where Z is standard normal and V chi-squared with ν degrees of freedom.
+func (t *StudentsT[S]) Rand() float64 {
+	z := t.c.g.src.NormFloat64()
+	return z / math.Sqrt(t.c.Rand() / t.nu)
+}
+
+// Fill fills dst with t-distributed pseudo-random float64s.
+func (t *StudentsT[S]) Fill(dst []float64) {
+	for i := range dst {
+		dst[i] = t.Rand()
+	}
+}
+
+// A F is a F-distribution with d1 and d2 degrees of freedom.
+type F[S Source] struct {
+	x, y   ChiSquared[S]
+	d1, d2 float64
+}
+
+// NewF returns a F-distribution with d1 and d2 degrees of freedom
+// using the generator src. d1 and d2 must be positive.
+func NewF[S Source](d1, d2 float64, src S) (*F[S], error) {
+	if !positive(d1) {
+		return nil, paramError("F", "d1", d1)
+	}
+	if !positive(d2) {
+		return nil, paramError("F", "d2", d2)
+	}
+	f := &F[S]{d1: d1, d2: d2}
+	f.x.k, f.y.k = d1, d2
+	f.x.g.init(d1 / 2, 2, src)
+	f.y.g.init(d2 / 2, 2, src)
+	return f, nil
+}
+
+// D1 returns the numerator degrees of freedom of f.
+func (f *F[S]) D1() float64 { return f.d1 }
+
+// D2 returns the denominator degrees of freedom of f.
+func (f *F[S]) D2() float64 { return f.d2 }
+
+// Rand returns a F-distributed pseudo-random float64 as (U/d1) / (V/d2),
+// where U and V are chi-squared variates with d1 and d2 degrees of freedom.
+func (f *F[S]) Rand() float64 {
+	return f.x.Rand() / f.d1 / (f.y.Rand() / f.d2)
+}
+
+// Fill fills dst with F-distributed pseudo-random float64s.
+func (f *F[S]) Fill(dst []float64) {
+	for i := range dst {
+		dst[i] = f.Rand()
+	}
+}
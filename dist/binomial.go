@@ -0,0 +1,169 @@
+package dist
+
+import (
+	"math"
+)
+
+// A Binomial is a binomial distribution of the number of successes in
+// n trials with success probability p.
+type Binomial[S Source] struct {
+	src  S
+	n    uint64
+	p    float64
+	flip bool    // p > 0.5 and the number of failures is sampled
+	q    float64 // min(p, 1-p)
+
+	// inversion constants
+	s, a, r0 float64
+
+	// BTRD constants
+	m, r, nr, c, b, alpha, vr float64
+	aa                        float64
+	logFm                     float64 // log f(m)
+}
+
+// NewBinomial returns a binomial distribution with n trials and success
+// probability p using the generator src. p must be in [0, 1] and n <= 2^53.
+func NewBinomial[S Source](n uint64, p float64, src S) (*Binomial[S], error) {
+	if n > maxFloatInt {
+		return nil, paramError("Binomial", "n", float64(n))
+	}
+	if !(p >= 0 && p <= 1) {
+		return nil, paramError("Binomial", "p", p)
+	}
+	d := &Binomial[S]{}
+	d.init(n, p, src)
+	return d, nil
+}
+
+func (d *Binomial[S]) init(n uint64, p float64, src S) {
+	*d = Binomial[S]{src: src, n: n, p: p}
+	d.q = p
+	if p > 0.5 {
+		d.flip = true
+		d.q = 1 - p
+	}
+	fn, q := float64(n), d.q
+	if fn * q < inversionLimit {
+		d.s = q / (1 - q)
+		d.a = (fn + 1) * d.s
+		d.r0 = math.Exp(fn * math.Log1p(-q))
+		return
+	}
+	spq := math.Sqrt(fn * q * (1 - q))
+	d.m = math.Floor((fn + 1) * q)
+	d.r = q / (1 - q)
+	d.nr = (fn + 1) * d.r
+	d.c = fn * q + 0.5
+	d.b = 1.15 + 2.53 * spq
+	d.aa = -0.0873 + 0.0248 * d.b + 0.01 * q
+	d.alpha = (2.83 + 5.1 / d.b) * spq
+	d.vr = 0.92 - 4.2 / d.b
+	d.logFm = d.logPmf(d.m)
+}
+
+// N returns the number of trials of d.
+func (d *Binomial[S]) N() uint64 { return d.n }
+
+// P returns the success probability of d.
+func (d *Binomial[S]) P() float64 { return d.p }
+
+// Rand returns a binomial distributed pseudo-random uint64.
+func (d *Binomial[S]) Rand() uint64 {
+	var k uint64
+	switch {
+	case d.q == 0:
+		k = 0
+	case float64(d.n) * d.q < inversionLimit:
+		k = d.inversion()
+	default:
+		k = d.btrd()
+	}
+	if d.flip {
+		return d.n - k
+	}
+	return k
+}
+
+// inversion returns a binomial variate by sequential search from 0 with
+// the probability recursion f(k) = f(k-1) ((n+1) s / k - s), s = q / (1-q).
+func (d *Binomial[S]) inversion() uint64 {
+	for {
+		u := d.src.Float64()
+		f := d.r0
+		for k := uint64(0); k <= d.n && k < 1000; k++ {
+			if u < f {
+				return k
+			}
+			u -= f
+			f *= d.a / float64(k + 1) - d.s
+		}
+	}
+}
+
+// btrd returns a binomial variate by Hörmann's transformed rejection with
+// decomposition BTRD, The generation of binomial random variates,
+// https://doi.org/10.1080/00949659308811496.
+func (d *Binomial[S]) btrd() uint64 {
+	fn := float64(d.n)
+	for {
+		u := d.src.Float64() - 0.5
+		v := d.src.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2 * d.aa / us + d.b) * u + d.c)
+		if us >= 0.07 && v <= d.vr {               // ~86% of cases
+			return uint64(k)
+		}
+		if k < 0 || k > fn {
+			continue
+		}
+		v = math.Log(v * d.alpha / (d.aa / (us * us) + d.b))
+		if v <= d.logPmf(k) - d.logFm {
+			return uint64(k)
+		}
+	}
+}
+
+// logPmf returns log f(k) of the binomial distribution of n and q by
+// Stirling's formula with the tails fc. The ratios (k+1)/((n+1)q) and
+// (n-k+1)/((n+1)(1-q)) are near 1, so their logarithms are computed by
+// log1p of d = k + 1 - (n+1)q and 1 - d, with d rounded once by FMA. The
+// terms of the size d cancel to O(d²/n) without the error of the size
+// n ε of log((n-m+1)/(n-k+1)) in the BTRD paper.
+func (d *Binomial[S]) logPmf(k float64) float64 {
+	fn, q := float64(d.n), d.q
+	c := fn + 1
+	dk := math.FMA(-c, q, k + 1)
+	return -(k + 0.5) * math.Log1p(dk / (c * q)) -
+		(fn - k + 0.5) * math.Log1p((1 - dk) / (c * (1 - q))) -
+		0.5 * math.Log(2 * math.Pi * c * q * (1 - q)) + 1 +
+		stirlingTail(fn) - stirlingTail(k) - stirlingTail(fn - k)
+}
+
+// Fill fills dst with binomial distributed pseudo-random uint64s.
+func (d *Binomial[S]) Fill(dst []uint64) {
+	for i := range dst {
+		dst[i] = d.Rand()
+	}
+}
+
+// stirlingTable has fc(k) for k < 10.
+var stirlingTable = func() (t [10]float64) {
+	for k := range t {
+		lg, _ := math.Lgamma(float64(k + 1))
+		x := float64(k)
+		t[k] = lg - (x + 0.5) * math.Log(x + 1) + (x + 1) - 0.5 * math.Log(2 * math.Pi)
+	}
+	return
+}()
+
+// stirlingTail returns the error fc(k) of Stirling's approximation
+// log k! = (k + 1/2) log(k + 1) - (k + 1) + log sqrt(2π) + fc(k).
+func stirlingTail(k float64) float64 {
+	if k < 10 {
+		return stirlingTable[int(k)]
+	}
+	k1 := k + 1
+	k2 := k1 * k1
+	return (1.0/12 - (1.0/360 - 1.0/1260 / k2) / k2) / k1
+}
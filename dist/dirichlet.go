@@ -0,0 +1,165 @@
+package dist
+
+import (
+	"math"
+)
+
+// A Dirichlet is a Dirichlet distribution with the concentration parameters
+// α_1, ..., α_k, density ∝ Π x_i^(α_i - 1) on the simplex Σ x_i = 1.
+type Dirichlet[S Source] struct {
+	alpha []float64
+	gamma []Gamma[S]
+	small bool // some α_i < 1, the gamma variates are combined in log scale
+	logs  []float64
+}
+
+// NewDirichlet returns a Dirichlet distribution with the parameters alpha
+// using the generator src. The parameters must be positive.
+func NewDirichlet[S Source](alpha []float64, src S) (*Dirichlet[S], error) {
+	if len(alpha) == 0 {
+		return nil, paramError("Dirichlet", "dimension", 0)
+	}
+	d := &Dirichlet[S]{
+		alpha: append([]float64(nil), alpha...),
+		gamma: make([]Gamma[S], len(alpha)),
+	}
+	for i, a := range alpha {
+		if !positive(a) {
+			return nil, paramError("Dirichlet", "alpha", a)
+		}
+		d.gamma[i].init(a, 1, src)
+		d.small = d.small || a < 1
+	}
+	if d.small {
+		d.logs = make([]float64, len(alpha))
+	}
+	return d, nil
+}
+
+// Alpha returns the i'th parameter of d.
+func (d *Dirichlet[S]) Alpha(i int) float64 { return d.alpha[i] }
+
+// Dim returns the dimension k of d.
+func (d *Dirichlet[S]) Dim() int { return len(d.alpha) }
+
+// Sample sets dst to a pseudo-random vector of d by normalized gamma variates
+// G_i / Σ G_j, G_i of shape α_i. For α_i < 1, log G_i = log G' - E/α_i with G'
+// of shape α_i + 1 and E exponential, and the vector is computed from the logs,
+// so that tiny α_i do not underflow all the G_i to 0.
+// Sample panics if len(dst) != d.Dim().
+func (d *Dirichlet[S]) Sample(dst []float64) {
+	if len(dst) != len(d.gamma) {
+		panic("invalid argument to Sample")
+	}
+	if !d.small {
+		for {
+			sum := 0.0
+			for i := range d.gamma {
+				dst[i] = d.gamma[i].standard()
+				sum += dst[i]
+			}
+			if sum > 0 {
+				for i := range dst {
+					dst[i] /= sum
+				}
+				return
+			}
+		}
+	}
+	lmax := math.Inf(-1)
+	for i := range d.gamma {
+		g := &d.gamma[i]
+		l := math.Log(g.standard())
+		if g.inv != 0 {
+			l -= g.src.ExpFloat64() * g.inv
+		}
+		d.logs[i] = l
+		lmax = max(lmax, l)
+	}
+	sum := 0.0
+	for i, l := range d.logs {
+		dst[i] = math.Exp(l - lmax)
+		sum += dst[i]
+	}
+	for i := range dst {
+		dst[i] /= sum
+	}
+}
+
+// A Multinomial is a multinomial distribution of the counts of k categories
+// in n trials with the category probabilities p_1, ..., p_k.
+type Multinomial[S Source] struct {
+	src  S
+	n    uint64
+	p    []float64
+	cond []float64 // p_i / Σ_{j>=i} p_j
+}
+
+// NewMultinomial returns a multinomial distribution with n trials and
+// the category probabilities p using the generator src. p is normalized to
+// the sum 1. The probabilities must be non-negative and finite with a positive
+// sum and n <= 2^53.
+func NewMultinomial[S Source](n uint64, p []float64, src S) (*Multinomial[S], error) {
+	if n > maxFloatInt {
+		return nil, paramError("Multinomial", "n", float64(n))
+	}
+	if len(p) == 0 {
+		return nil, paramError("Multinomial", "categories", 0)
+	}
+	d := &Multinomial[S]{
+		src:  src,
+		n:    n,
+		p:    make([]float64, len(p)),
+		cond: make([]float64, len(p)),
+	}
+	// The suffix sums are computed backwards, not by subtracting from 1.
+	rest := 0.0
+	for i := len(p) - 1; i >= 0; i-- {
+		if !(p[i] >= 0 && p[i] <= math.MaxFloat64) {
+			return nil, paramError("Multinomial", "p", p[i])
+		}
+		rest += p[i]
+		if rest > 0 {
+			d.cond[i] = min(1, p[i] / rest)
+		}
+	}
+	if !positive(rest) {
+		return nil, paramError("Multinomial", "p sum", rest)
+	}
+	for i := range p {
+		d.p[i] = p[i] / rest
+	}
+	return d, nil
+}
+
+// N returns the number of trials of d.
+func (d *Multinomial[S]) N() uint64 { return d.n }
+
+// P returns the probability of the category i of d.
+func (d *Multinomial[S]) P(i int) float64 { return d.p[i] }
+
+// Sample sets dst to pseudo-random counts of d by sequential conditional
+// binomials: the count of category i is binomial with the remaining trials
+// and the probability p_i / Σ_{j>=i} p_j. The time is O(k) for any n.
+// Sample panics if len(dst) != len(p).
+func (d *Multinomial[S]) Sample(dst []uint64) {
+	if len(dst) != len(d.cond) {
+		panic("invalid argument to Sample")
+	}
+	var b Binomial[S]
+	n := d.n
+	for i, q := range d.cond {
+		if n == 0 || q == 0 {
+			dst[i] = 0
+			continue
+		}
+		if q == 1 {
+			dst[i] = n
+			n = 0
+			continue
+		}
+		b.init(n, q, d.src)
+		dst[i] = b.Rand()
+		n -= dst[i]
+	}
+}
@@ -0,0 +1,144 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+func TestDirichlet(t *testing.T) {
+	x := prng.NewXosh(1)
+	r := prng.New(1)
+	for _, alpha := range [][]float64{{1, 1}, {0.5, 2, 3}, {0.05, 0.3, 1, 10}, {40, 7}} {
+		testDirichlet(t, alpha, &x)
+		testDirichlet(t, alpha, &r)
+	}
+	// Tiny parameters underflow the gamma variates.
+	d, err := NewDirichlet([]float64{1e-3, 1e-4, 1e-5}, &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := make([]float64, 3)
+	for range 10000 {
+		d.Sample(v)
+		if s := v[0] + v[1] + v[2]; !(abs(s - 1) < 1e-15) {
+			t.Fatalf("tiny alpha sample %v", v)
+		}
+	}
+}
+
+// testDirichlet tests the marginals Beta(α_i, α_0 - α_i) of a Dirichlet.
+func testDirichlet[S Source](t *testing.T, alpha []float64, src S) {
+	d, err := NewDirichlet(alpha, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := len(alpha)
+	a0 := 0.0
+	for _, a := range alpha {
+		a0 += a
+	}
+	v := make([]float64, k)
+	marg := make([][]float64, k)
+	for range rounds / 4 {
+		d.Sample(v)
+		s := 0.0
+		for i := range v {
+			marg[i] = append(marg[i], v[i])
+			s += v[i]
+		}
+		if abs(s - 1) > 1e-14 {
+			t.Fatalf("Dirichlet(%v) sum = %g", alpha, s)
+		}
+	}
+	for i, a := range alpha {
+		dd, lim := ksTest(marg[i], func(x float64) float64 { return betaI(a, a0-a, x) })
+		if dd > lim {
+			t.Errorf("Dirichlet(%v) marginal %d KS D = %f > %f", alpha, i, dd, lim)
+		}
+	}
+}
+
+func TestMultinomial(t *testing.T) {
+	const rounds = 200000
+	x := prng.NewXoro(1)
+	p := []float64{0.2, 0, 0.5, 0.05, 0.25, 0}
+	for _, n := range []uint64{0, 1, 7, 100, 10000} {
+		d, err := NewMultinomial(n, p, &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := make([]uint64, len(p))
+		marg := make([][]uint64, len(p))
+		for i := range marg {
+			marg[i] = make([]uint64, rounds)
+		}
+		for r := range rounds {
+			d.Sample(v)
+			s := uint64(0)
+			for i := range v {
+				marg[i][r] = v[i]
+				s += v[i]
+			}
+			if s != n {
+				t.Fatalf("Multinomial(%d) sum %d", n, s)
+			}
+		}
+		for i, q := range p {
+			if n == 0 {
+				break
+			}
+			mean := float64(n) * q
+			lo, hi, mode := span(mean, math.Sqrt(mean*(1-q)))
+			hi, mode = min(hi, n), min(mode, n)
+			pmf, w := pmfRange(lo, hi, mode, func(k uint64) float64 {
+				return float64(n-k) / float64(k+1) * q / (1 - q)
+			})
+			chiSquareCells(t, "Multinomial("+ftoa(float64(n))+") "+ftoa(q), marg[i], lo, w, pmf)
+		}
+	}
+}
+
+func TestMultinomialLarge(t *testing.T) {
+	x := prng.NewXosh(1)
+	const n = 1 << 53
+	p := []float64{3, 1e-10, 1, 1e-15, 2}
+	d, err := NewMultinomial(n, p, &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := make([]uint64, len(p))
+	for range 1000 {
+		d.Sample(v)
+		s := uint64(0)
+		for i := range v {
+			q := d.P(i)
+			if z := (float64(v[i]) - n * q) / math.Sqrt(n * q * (1 - q)); abs(z) > 6 {
+				t.Fatalf("count %d = %d, z = %f", i, v[i], z)
+			}
+			s += v[i]
+		}
+		if s != n {
+			t.Fatalf("sum %d", s)
+		}
+	}
+}
+
+func TestDirichletMultinomialParameters(t *testing.T) {
+	x := prng.NewMCG(1)
+	for _, a := range [][]float64{nil, {1, 0}, {1, -1}, {math.NaN()}, {math.Inf(1), 1}} {
+		if _, err := NewDirichlet(a, &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewDirichlet(%v): %v", a, err)
+		}
+	}
+	for _, p := range [][]float64{nil, {0, 0}, {1, -1}, {math.NaN()}, {math.Inf(1), 1}} {
+		if _, err := NewMultinomial(10, p, &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewMultinomial(%v): %v", p, err)
+		}
+	}
+	if _, err := NewMultinomial(1<<53+1, []float64{1}, &x); !errors.Is(err, ErrParameter) {
+		t.Errorf("NewMultinomial(2^53+1): %v", err)
+	}
+}
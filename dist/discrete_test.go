@@ -0,0 +1,163 @@
+package dist
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+// maxCells is the maximum number of cells of pmfRange.
+const maxCells = 1 << 16
+
+// pmfRange returns the exact probabilities of k in [lo, hi] by the ratios
+// f(k+1)/f(k) = ratio(k) from the mode, normalized to the sum 1. The range must
+// include all but negligible probability. A range longer than maxCells is
+// binned to cells [lo + i*w, lo + (i+1)*w) of width w, so the memory is
+// bounded for any range. pmf[i] is the probability of the cell i.
+func pmfRange(lo, hi, mode uint64, ratio func(k uint64) float64) (pmf []float64, w uint64) {
+	w = (hi-lo)/maxCells + 1
+	pmf = make([]float64, (hi-lo)/w+1)
+	i := (mode - lo) / w
+	f, c, end := 1.0, 0.0, lo+(i+1)*w // c sums the cell i up to end
+	for k := mode; ; k++ {
+		if k == end {
+			pmf[i] += c
+			i, c, end = i+1, 0, end+w
+		}
+		c += f
+		if k == hi {
+			break
+		}
+		f *= ratio(k)
+	}
+	pmf[i] += c
+	i = (mode - lo) / w
+	f, c, end = 1.0, 0.0, lo+i*w // c sums the cell i down to end
+	for k := mode; k > lo; k-- {
+		f /= ratio(k - 1)
+		if k-1 < end {
+			pmf[i] += c
+			i, c, end = i-1, 0, end-w
+		}
+		c += f
+	}
+	pmf[i] += c
+	sum := 0.0
+	for _, x := range pmf {
+		sum += x
+	}
+	for i := range pmf {
+		pmf[i] /= sum
+	}
+	return pmf, w
+}
+
+// chiSquare tests the counts of samples in [lo, lo+len(pmf)) against the pmf.
+// The cells are merged to expected counts >= 20.
+func chiSquare(t *testing.T, name string, sample []uint64, lo uint64, pmf []float64) {
+	chiSquareCells(t, name, sample, lo, 1, pmf)
+}
+
+// chiSquareCells is chiSquare for the cells of width w of pmfRange.
+func chiSquareCells(t *testing.T, name string, sample []uint64, lo, w uint64, pmf []float64) {
+	counts := make([]float64, len(pmf))
+	for _, k := range sample {
+		if k < lo || (k-lo)/w >= uint64(len(pmf)) {
+			t.Errorf("%s: sample %d out of range", name, k)
+			return
+		}
+		counts[(k-lo)/w]++
+	}
+	n := float64(len(sample))
+	chi2, df := 0.0, -1.0
+	obs, exp := 0.0, 0.0
+	for i := range pmf {
+		obs += counts[i]
+		exp += pmf[i] * n
+		if exp >= 20 || i == len(pmf)-1 {
+			if exp > 0 {
+				chi2 += (obs - exp) * (obs - exp) / exp
+				df++
+			} else if obs > 0 {
+				t.Errorf("%s: %f samples with zero probability", name, obs)
+			}
+			obs, exp = 0, 0
+		}
+	}
+	if df < 1 {
+		return
+	}
+	lim := df + 5*math.Sqrt(2*df)
+	t.Logf("%-28s chi2 = %8.1f df = %4.0f", name, chi2, df)
+	if chi2 > lim {
+		t.Errorf("%s: chi2 = %f > %f with df %f", name, chi2, lim, df)
+	}
+}
+
+func span(mean, sd float64) (lo, hi, mode uint64) {
+	l := math.Max(0, math.Floor(mean-12*sd-10))
+	return uint64(l), uint64(mean + 12*sd + 20), uint64(mean)
+}
+
+func TestPoisson(t *testing.T) {
+	const rounds = 1000000
+	x := prng.NewXosh(1)
+	sample := make([]uint64, rounds)
+	means := []float64{0, 0.1, 3, 9.99, 10, 57.3, 1e4, 1e9, 1e13, 1e15}
+	if !testing.Short() {
+		means = append(means, 4e15, 1<<53) // the pmf walk takes 10 s each
+	}
+	for _, mean := range means {
+		p, err := NewPoisson(mean, &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Fill(sample)
+		lo, hi, mode := span(mean, math.Sqrt(mean))
+		pmf, w := pmfRange(lo, hi, mode, func(k uint64) float64 { return mean / float64(k+1) })
+		chiSquareCells(t, "Poisson("+ftoa(mean)+")", sample, lo, w, pmf)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	const rounds = 1000000
+	x := prng.New(1)
+	sample := make([]uint64, rounds)
+	type param struct {
+		n uint64
+		p float64
+	}
+	params := []param{
+		{0, 0.5}, {1, 0.5}, {10, 0.3}, {100, 0.05}, {1000, 0.00999}, {1000, 0.5},
+		{50, 0.9}, {1e12, 1e-11}, {1e12, 1e-9}, {1e12, 0.3}, {1e12, 0.9999}, {20, 1}, {20, 0},
+		{1e15, 0.5},
+	}
+	if !testing.Short() {
+		params = append(params, param{1 << 53, 0.3})
+	}
+	for _, c := range params {
+		n, p := c.n, c.p
+		d, err := NewBinomial(n, p, &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Fill(sample)
+		mean := float64(n) * p
+		lo, hi, mode := span(mean, math.Sqrt(mean*(1-p)))
+		if hi > n {
+			hi = n
+		}
+		if mode > n {
+			mode = n
+		}
+		pmf, w := pmfRange(lo, hi, mode, func(k uint64) float64 {
+			return float64(n-k) / float64(k+1) * p / (1 - p)
+		})
+		if p == 1 {
+			pmf = make([]float64, hi-lo+1)
+			pmf[n-lo] = 1
+		}
+		chiSquareCells(t, "Binomial("+ftoa(float64(n))+", "+ftoa(p)+")", sample, lo, w, pmf)
+	}
+}
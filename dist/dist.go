@@ -0,0 +1,50 @@
+// Package dist implements random variate generators of probability
+// distributions on top of the prng generators.
+//
+// A distribution type has a type parameter S, the generator used. S is
+// a pointer to a generator: *prng.Prng, *prng.XoshPrng, *prng.MCGPrng,
+// *prng.Xoro, *prng.Xosh or *prng.MCG.
+// The distribution holds the pointer, not a copy of the generator,
+// so the random stream of the generator is used by the distribution and
+// the other users of the generator in the order of the calls. A generator
+// from prng.Next or prng.NewPrngSlice can be given to the distributions of
+// a single goroutine.
+//
+//	x := prng.NextXosh()
+//	g, err := dist.NewGamma(2.5, 1, &x)
+//	if err != nil { ... }
+//	y := g.Rand()
+//
+// The constructors validate the parameters and return an error wrapping
+// ErrParameter for invalid parameters.
+package dist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A Source is a pseudo-random number generator of package prng.
+// Go compiles the generic distributions once for all pointer type
+// arguments S, so the methods of S are called indirectly through the
+// dictionary of the type arguments as through an interface, and they are
+// not inlined into the distributions.
+type Source interface {
+	Uint64() uint64
+	Float64() float64
+	Float64full() float64
+	NormFloat64() float64
+	ExpFloat64() float64
+}
+
+// ErrParameter is the error wrapped by the errors of invalid parameters.
+var ErrParameter = errors.New("dist: invalid parameter")
+
+func paramError(dist, param string, value float64) error {
+	return fmt.Errorf("%w: %s %s %g", ErrParameter, dist, param, value)
+}
+
+// positive reports whether x is a positive finite float64.
+func positive(x float64) bool {
+	return x > 0 && x <= 1.7976931348623157e308
+}
@@ -0,0 +1,255 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+func abs(x float64) float64 {
+	if x > 0 {
+		return x
+	}
+	return -x
+}
+
+// ksTest returns the one-sample Kolmogorov-Smirnov statistic D of a against
+// the cdf and the critical value of D for alpha 0.001. a is sorted in place.
+func ksTest(a []float64, cdf func(float64) float64) (d, lim float64) {
+	sort.Float64s(a)
+	n := float64(len(a))
+	for i, x := range a {
+		c := cdf(x)
+		d = math.Max(d, math.Max(c-float64(i)/n, float64(i+1)/n-c))
+	}
+	return d, 1.95 / math.Sqrt(n)
+}
+
+// gammaP returns the regularized lower incomplete gamma function P(a, x).
+func gammaP(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	lg, _ := math.Lgamma(a)
+	front := math.Exp(a*math.Log(x) - x - lg)
+	if x < a+1 {
+		// series
+		sum, term := 1/a, 1/a
+		for n := 1.0; n < 1000; n++ {
+			term *= x / (a + n)
+			sum += term
+			if term < sum*1e-16 {
+				break
+			}
+		}
+		return front * sum
+	}
+	// continued fraction for Q(a, x) by modified Lentz
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1.0; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if abs(del-1) < 1e-16 {
+			break
+		}
+	}
+	return 1 - front*h
+}
+
+// betaI returns the regularized incomplete beta function I_x(a, b).
+func betaI(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	if x > (a+1)/(a+b+2) {
+		return 1 - betaI(b, a, 1-x)
+	}
+	// continued fraction by modified Lentz
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1.0; m < 1000; m++ {
+		m2 := 2 * m
+		for _, an := range []float64{
+			m * (b - m) * x / ((a + m2 - 1) * (a + m2)),
+			-(a + m) * (a + b + m) * x / ((a + m2) * (a + m2 + 1)),
+		} {
+			d = 1 + an*d
+			if abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + an/c
+			if abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if abs(d*c-1) < 1e-16 {
+			break
+		}
+	}
+	return front * h / a
+}
+
+func tCDF(nu, t float64) float64 {
+	p := 0.5 * betaI(nu/2, 0.5, nu/(nu+t*t))
+	if t > 0 {
+		return 1 - p
+	}
+	return p
+}
+
+const rounds = 200000
+
+func TestGamma(t *testing.T) {
+	x := prng.NewXosh(1)
+	a := make([]float64, rounds)
+	for _, p := range [][2]float64{{0.05, 1}, {0.5, 2}, {1, 1}, {2.5, 0.5}, {30, 3}} {
+		g, err := NewGamma(p[0], p[1], &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.Fill(a)
+		d, lim := ksTest(a, func(x float64) float64 { return gammaP(p[0], x/p[1]) })
+		t.Logf("Gamma(%g, %g) KS D = %f", p[0], p[1], d)
+		if d > lim {
+			t.Errorf("Gamma(%g, %g) KS D = %f > %f", p[0], p[1], d, lim)
+		}
+	}
+}
+
+func TestChiSquared(t *testing.T) {
+	r := prng.New(1)
+	a := make([]float64, rounds)
+	for _, k := range []float64{1, 3, 10, 100} {
+		c, err := NewChiSquared(k, &r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Fill(a)
+		d, lim := ksTest(a, func(x float64) float64 { return gammaP(k/2, x/2) })
+		t.Logf("ChiSquared(%g) KS D = %f", k, d)
+		if d > lim {
+			t.Errorf("ChiSquared(%g) KS D = %f > %f", k, d, lim)
+		}
+	}
+}
+
+func TestBeta(t *testing.T) {
+	x := prng.NewXoro(1)
+	a := make([]float64, rounds)
+	for _, p := range [][2]float64{{0.5, 0.5}, {2, 5}, {0.1, 0.2}, {1, 1}, {50, 20}} {
+		b, err := NewBeta(p[0], p[1], &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Fill(a)
+		d, lim := ksTest(a, func(x float64) float64 { return betaI(p[0], p[1], x) })
+		t.Logf("Beta(%g, %g) KS D = %f", p[0], p[1], d)
+		if d > lim {
+			t.Errorf("Beta(%g, %g) KS D = %f > %f", p[0], p[1], d, lim)
+		}
+	}
+}
+
+func TestStudentsT(t *testing.T) {
+	x := prng.NewXosh(1)
+	a := make([]float64, rounds)
+	for _, nu := range []float64{1, 2.5, 30} {
+		s, err := NewStudentsT(nu, &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Fill(a)
+		d, lim := ksTest(a, func(x float64) float64 { return tCDF(nu, x) })
+		t.Logf("StudentsT(%g) KS D = %f", nu, d)
+		if d > lim {
+			t.Errorf("StudentsT(%g) KS D = %f > %f", nu, d, lim)
+		}
+	}
+}
+
+func TestF(t *testing.T) {
+	x := prng.NewMCG(1)
+	a := make([]float64, rounds)
+	for _, p := range [][2]float64{{2, 5}, {10, 20}, {1, 1}} {
+		f, err := NewF(p[0], p[1], &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Fill(a)
+		d1, d2 := p[0], p[1]
+		d, lim := ksTest(a, func(x float64) float64 { return betaI(d1/2, d2/2, d1*x/(d1*x+d2)) })
+		t.Logf("F(%g, %g) KS D = %f", d1, d2, d)
+		if d > lim {
+			t.Errorf("F(%g, %g) KS D = %f > %f", d1, d2, d, lim)
+		}
+	}
+}
+
+func TestParameters(t *testing.T) {
+	x := prng.NewXoro(1)
+	for _, v := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		errs := []error{}
+		_, err := NewGamma(v, 1, &x)
+		errs = append(errs, err)
+		_, err = NewGamma(1, v, &x)
+		errs = append(errs, err)
+		_, err = NewBeta(v, 1, &x)
+		errs = append(errs, err)
+		_, err = NewChiSquared(v, &x)
+		errs = append(errs, err)
+		_, err = NewStudentsT(v, &x)
+		errs = append(errs, err)
+		_, err = NewF(1, v, &x)
+		errs = append(errs, err)
+		if v != 0 {
+			_, err = NewPoisson(v, &x)
+			errs = append(errs, err)
+			_, err = NewBinomial(10, v, &x)
+			errs = append(errs, err)
+		}
+		_, err = NewBinomial(1<<53+1, 0.5, &x)
+		errs = append(errs, err)
+		for i, err := range errs {
+			if !errors.Is(err, ErrParameter) {
+				t.Errorf("parameter %g case %d: err = %v", v, i, err)
+			}
+		}
+	}
+}
+
+func ftoa(x float64) string {
+	return strconv.FormatFloat(x, 'g', 4, 64)
+}
@@ -0,0 +1,230 @@
+package dist
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// The exact samplers use only the bits of a generator and integer
+// arithmetic, no floating point. They follow Canonne, Kamath & Steinke:
+// The Discrete Gaussian for Differential Privacy, https://arxiv.org/abs/2004.00010.
+
+// exact draws exact random variates of rational parameters.
+type exact[S Source] struct {
+	src S
+}
+
+// uniform returns a uniform integer in [0, m), m >= 1, by rejection of
+// the bits above m-1.
+func (e exact[S]) uniform(m uint64) uint64 {
+	if m == 1 {
+		return 0
+	}
+	mask := ^uint64(0) >> bits.LeadingZeros64(m - 1)
+	for {
+		if u := e.src.Uint64() & mask; u < m {
+			return u
+		}
+	}
+}
+
+// uniformBig returns a uniform integer in [0, m), m >= 1, to z.
+func (e exact[S]) uniformBig(z, m *big.Int) *big.Int {
+	n := m.BitLen()
+	words := make([]big.Word, (n + bits.UintSize - 1) / bits.UintSize)
+	for {
+		for i := range words {
+			words[i] = big.Word(e.src.Uint64())
+		}
+		if r := n % bits.UintSize; r != 0 {
+			words[len(words)-1] &= 1<<r - 1
+		}
+		if z.SetBits(words).Cmp(m) < 0 {
+			return z
+		}
+	}
+}
+
+// bernoulli returns true with probability num/den, num <= den.
+func (e exact[S]) bernoulli(num, den uint64) bool {
+	return e.uniform(den) < num
+}
+
+// expMinus returns true with probability exp(-num/den).
+func (e exact[S]) expMinus(num, den uint64) bool {
+	for ; num > den; num -= den {
+		if !e.expFrac(1, 1) {
+			return false
+		}
+	}
+	return e.expFrac(num, den)
+}
+
+// expFrac returns true with probability exp(-γ), γ = num/den in [0, 1].
+// With the Bernoulli(γ/k) trials A_k, exp(-γ) = P(the first failing k is odd).
+// Bernoulli(γ/k) is Bernoulli(γ) and Bernoulli(1/k).
+func (e exact[S]) expFrac(num, den uint64) bool {
+	k := uint64(1)
+	for e.bernoulli(num, den) && e.uniform(k) == 0 {
+		k++
+	}
+	return k & 1 == 1
+}
+
+// expMinusBig returns true with probability exp(-num/den).
+func (e exact[S]) expMinusBig(num, den *big.Int) bool {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	// For q >= 2^64 the loop runs until a failure, with probability
+	// 1 - exp(-2^64) before q trials.
+	for i := uint64(0); !q.IsUint64() || i < q.Uint64(); i++ {
+		if !e.expFrac(1, 1) {
+			return false
+		}
+	}
+	k := uint64(1)
+	z := new(big.Int)
+	for e.uniformBig(z, den).Cmp(r) < 0 && e.uniform(k) == 0 {
+		k++
+	}
+	return k & 1 == 1
+}
+
+// laplace returns a discrete Laplace variate of the scale t/s,
+// probability ∝ exp(-|x| s/t).
+func (e exact[S]) laplace(s, t uint64) int64 {
+	for {
+		u := e.uniform(t)
+		if !e.expMinus(u, t) {
+			continue
+		}
+		v := uint64(0)
+		for e.expFrac(1, 1) {
+			v++
+		}
+		// X = U + tV overflows with a probability below exp(-2^31).
+		hi, x := bits.Mul64(t, v)
+		x, carry := bits.Add64(x, u, 0)
+		if hi != 0 || carry != 0 || x > 1<<62 {
+			continue
+		}
+		y := int64(x / s)
+		if e.src.Uint64() >> 63 == 1 {
+			if y == 0 {
+				continue
+			}
+			return -y
+		}
+		return y
+	}
+}
+
+// A BernoulliExp is a Bernoulli distribution with the success probability
+// exp(-γ) for a rational γ >= 0, sampled exactly.
+type BernoulliExp[S Source] struct {
+	e        exact[S]
+	num, den uint64
+}
+
+// NewBernoulliExp returns a Bernoulli distribution with the success probability
+// exp(-num/den) using the generator src. den must be positive.
+func NewBernoulliExp[S Source](num, den uint64, src S) (*BernoulliExp[S], error) {
+	if den == 0 {
+		return nil, paramError("BernoulliExp", "den", 0)
+	}
+	return &BernoulliExp[S]{e: exact[S]{src}, num: num, den: den}, nil
+}
+
+// Rand returns true with probability exp(-num/den).
+func (b *BernoulliExp[S]) Rand() bool {
+	return b.e.expMinus(b.num, b.den)
+}
+
+// A DiscreteLaplace is a discrete Laplace distribution on the integers with
+// a rational scale t, probability (1-e^(-1/t)) / (1+e^(-1/t)) e^(-|x|/t).
+type DiscreteLaplace[S Source] struct {
+	e        exact[S]
+	num, den uint64
+}
+
+// NewDiscreteLaplace returns a discrete Laplace distribution with the scale
+// num/den using the generator src. num and den must be in [1, 2^32].
+func NewDiscreteLaplace[S Source](num, den uint64, src S) (*DiscreteLaplace[S], error) {
+	if num == 0 || num > 1<<32 {
+		return nil, paramError("DiscreteLaplace", "num", float64(num))
+	}
+	if den == 0 || den > 1<<32 {
+		return nil, paramError("DiscreteLaplace", "den", float64(den))
+	}
+	return &DiscreteLaplace[S]{e: exact[S]{src}, num: num, den: den}, nil
+}
+
+// Scale returns the scale of d as num, den.
+func (d *DiscreteLaplace[S]) Scale() (num, den uint64) { return d.num, d.den }
+
+// Rand returns an exactly discrete Laplace distributed pseudo-random int64.
+func (d *DiscreteLaplace[S]) Rand() int64 {
+	return d.e.laplace(d.den, d.num)
+}
+
+// Fill fills dst with discrete Laplace distributed pseudo-random int64s.
+func (d *DiscreteLaplace[S]) Fill(dst []int64) {
+	for i := range dst {
+		dst[i] = d.Rand()
+	}
+}
+
+// A DiscreteGaussian is a discrete Gaussian distribution on the integers with
+// a rational variance parameter σ², probability ∝ exp(-x²/(2σ²)).
+type DiscreteGaussian[S Source] struct {
+	e        exact[S]
+	num, den uint64
+	t        uint64   // floor(σ) + 1, the scale of the Laplace proposal
+	gden     *big.Int // 2 num t² den, the denominator of the acceptance exponent
+}
+
+// NewDiscreteGaussian returns a discrete Gaussian distribution with the
+// variance parameter σ² = num/den using the generator src. num and den must
+// be in [1, 2^32].
+func NewDiscreteGaussian[S Source](num, den uint64, src S) (*DiscreteGaussian[S], error) {
+	if num == 0 || num > 1<<32 {
+		return nil, paramError("DiscreteGaussian", "num", float64(num))
+	}
+	if den == 0 || den > 1<<32 {
+		return nil, paramError("DiscreteGaussian", "den", float64(den))
+	}
+	d := &DiscreteGaussian[S]{e: exact[S]{src}, num: num, den: den}
+	// floor(σ) = floor(sqrt(floor(σ²))).
+	d.t = new(big.Int).Sqrt(new(big.Int).SetUint64(num / den)).Uint64() + 1
+	t := new(big.Int).SetUint64(d.t)
+	d.gden = new(big.Int).SetUint64(2 * num)
+	d.gden.Mul(d.gden, t).Mul(d.gden, t).Mul(d.gden, new(big.Int).SetUint64(den))
+	return d, nil
+}
+
+// Sigma2 returns the variance parameter of d as num, den.
+func (d *DiscreteGaussian[S]) Sigma2() (num, den uint64) { return d.num, d.den }
+
+// Rand returns an exactly discrete Gaussian distributed pseudo-random int64.
+// A discrete Laplace proposal Y of the scale t is accepted with the probability
+// exp(-(|Y| - σ²/t)² / (2σ²)) = exp(-(|Y| t den - num)² / (2 num t² den)).
+func (d *DiscreteGaussian[S]) Rand() int64 {
+	n := new(big.Int)
+	num := new(big.Int).SetUint64(d.num)
+	td := new(big.Int).SetUint64(d.t * d.den)
+	for {
+		y := d.e.laplace(1, d.t)
+		n.SetInt64(y)
+		n.Abs(n).Mul(n, td).Sub(n, num)
+		n.Mul(n, n)
+		if d.e.expMinusBig(n, d.gden) {
+			return y
+		}
+	}
+}
+
+// Fill fills dst with discrete Gaussian distributed pseudo-random int64s.
+func (d *DiscreteGaussian[S]) Fill(dst []int64) {
+	for i := range dst {
+		dst[i] = d.Rand()
+	}
+}
@@ -0,0 +1,98 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+func TestBernoulliExp(t *testing.T) {
+	const rounds = 1000000
+	x := prng.NewXoro(1)
+	for _, g := range [][2]uint64{{0, 1}, {1, 3}, {1, 1}, {7, 2}, {10, 1}, {1<<40, 1<<41}} {
+		b, err := NewBernoulliExp(g[0], g[1], &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := math.Exp(-float64(g[0]) / float64(g[1]))
+		n := 0.0
+		for range rounds {
+			if b.Rand() {
+				n++
+			}
+		}
+		if z := (n - rounds * p) / math.Sqrt(rounds * p * (1 - p) + 1e-300); abs(z) > 5 {
+			t.Errorf("BernoulliExp(%d/%d): %0.f successes of %d, z = %f", g[0], g[1], n, rounds, z)
+		}
+	}
+}
+
+// testIntPmf tests the samples against the pmf of the integers in [-m, m].
+func testIntPmf(t *testing.T, name string, sample []int64, m int64, pmf func(x int64) float64) {
+	p := make([]float64, 2 * m + 1)
+	u := make([]uint64, len(sample))
+	for x := -m; x <= m; x++ {
+		p[x + m] = pmf(x)
+	}
+	for i, x := range sample {
+		u[i] = uint64(x + m)
+	}
+	chiSquare(t, name, u, 0, p)
+}
+
+func TestDiscreteLaplace(t *testing.T) {
+	const rounds = 1000000
+	r := prng.New(1)
+	sample := make([]int64, rounds)
+	for _, c := range [][2]uint64{{1, 1}, {1, 3}, {5, 2}, {40, 1}} {
+		d, err := NewDiscreteLaplace(c[0], c[1], &r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Fill(sample)
+		b := float64(c[0]) / float64(c[1])
+		q := math.Exp(-1 / b)
+		testIntPmf(t, "DiscreteLaplace("+ftoa(b)+")", sample, int64(60 * b + 10), func(x int64) float64 {
+			return (1 - q) / (1 + q) * math.Pow(q, math.Abs(float64(x)))
+		})
+	}
+}
+
+func TestDiscreteGaussian(t *testing.T) {
+	const rounds = 400000
+	x := prng.NewXosh(1)
+	sample := make([]int64, rounds)
+	for _, c := range [][2]uint64{{1, 4}, {1, 1}, {9, 2}, {100, 1}, {1 << 32, 1 << 22}} {
+		d, err := NewDiscreteGaussian(c[0], c[1], &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Fill(sample)
+		s2 := float64(c[0]) / float64(c[1])
+		m := int64(12 * math.Sqrt(s2) + 5)
+		sum := 0.0
+		for k := -m; k <= m; k++ {
+			sum += math.Exp(-float64(k * k) / (2 * s2))
+		}
+		testIntPmf(t, "DiscreteGaussian("+ftoa(s2)+")", sample, m, func(x int64) float64 {
+			return math.Exp(-float64(x * x) / (2 * s2)) / sum
+		})
+	}
+}
+
+func TestExactParameters(t *testing.T) {
+	x := prng.NewXoro(1)
+	if _, err := NewBernoulliExp(1, 0, &x); !errors.Is(err, ErrParameter) {
+		t.Errorf("NewBernoulliExp(1, 0): %v", err)
+	}
+	for _, c := range [][2]uint64{{0, 1}, {1, 0}, {1<<32 + 1, 1}, {1, 1<<32 + 1}} {
+		if _, err := NewDiscreteLaplace(c[0], c[1], &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewDiscreteLaplace%v: %v", c, err)
+		}
+		if _, err := NewDiscreteGaussian(c[0], c[1], &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewDiscreteGaussian%v: %v", c, err)
+		}
+	}
+}
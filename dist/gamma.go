@@ -0,0 +1,116 @@
+package dist
+
+import (
+	"math"
+)
+
+// A Gamma is a gamma distribution with shape k and scale θ,
+// density x^(k-1) e^(-x/θ) / (Γ(k) θ^k).
+type Gamma[S Source] struct {
+	src   S
+	shape float64
+	scale float64
+	d, c  float64 // Marsaglia-Tsang constants of shape or shape + 1
+	inv   float64 // 1 / shape for boosting shape < 1
+}
+
+// NewGamma returns a gamma distribution with the shape and scale
+// using the generator src. shape and scale must be positive.
+func NewGamma[S Source](shape, scale float64, src S) (*Gamma[S], error) {
+	if !positive(shape) {
+		return nil, paramError("Gamma", "shape", shape)
+	}
+	if !positive(scale) {
+		return nil, paramError("Gamma", "scale", scale)
+	}
+	g := &Gamma[S]{}
+	g.init(shape, scale, src)
+	return g, nil
+}
+
+func (g *Gamma[S]) init(shape, scale float64, src S) {
+	g.src, g.shape, g.scale = src, shape, scale
+	a := shape
+	if a < 1 {
+		g.inv = 1 / a
+		a++
+	}
+	g.d = a - 1.0/3
+	g.c = 1 / math.Sqrt(9 * g.d)
+}
+
+// Shape returns the shape parameter of g.
+func (g *Gamma[S]) Shape() float64 { return g.shape }
+
+// Scale returns the scale parameter of g.
+func (g *Gamma[S]) Scale() float64 { return g.scale }
+
+// Rand returns a gamma distributed pseudo-random float64.
+// Rand uses Marsaglia & Tsang: A Simple Method for Generating Gamma Variables,
+// https://dl.acm.org/doi/10.1145/358407.358414. For shape k < 1 a gamma variate
+// of shape k + 1 is boosted by U^(1/k) = e^(-E/k), E exponential.
+func (g *Gamma[S]) Rand() float64 {
+	x := g.standard()
+	if g.inv != 0 {
+		x *= math.Exp(-g.src.ExpFloat64() * g.inv)
+	}
+	return x * g.scale
+}
+
+// standard returns a gamma variate with shape d + 1/3 and scale 1.
+func (g *Gamma[S]) standard() float64 {
+	for {
+		z := g.src.NormFloat64()
+		v := 1 + g.c * z
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := g.src.Float64()
+		z2 := z * z
+		if u < 1 - 0.0331 * z2 * z2 {              // squeeze, ~98% of cases
+			return g.d * v
+		}
+		if math.Log(u) < 0.5 * z2 + g.d * (1 - v + math.Log(v)) {
+			return g.d * v
+		}
+	}
+}
+
+// Fill fills dst with gamma distributed pseudo-random float64s.
+func (g *Gamma[S]) Fill(dst []float64) {
+	for i := range dst {
+		dst[i] = g.Rand()
+	}
+}
+
+// A ChiSquared is a chi-squared distribution with k degrees of freedom,
+// a gamma distribution with shape k/2 and scale 2.
+type ChiSquared[S Source] struct {
+	g Gamma[S]
+	k float64
+}
+
+// NewChiSquared returns a chi-squared distribution with k degrees of freedom
+// using the generator src. k must be positive.
+func NewChiSquared[S Source](k float64, src S) (*ChiSquared[S], error) {
+	if !positive(k) {
+		return nil, paramError("ChiSquared", "k", k)
+	}
+	c := &ChiSquared[S]{k: k}
+	c.g.init(k / 2, 2, src)
+	return c, nil
+}
+
+// K returns the degrees of freedom of c.
+func (c *ChiSquared[S]) K() float64 { return c.k }
+
+// Rand returns a chi-squared distributed pseudo-random float64.
+func (c *ChiSquared[S]) Rand() float64 {
+	return c.g.Rand()
+}
+
+// Fill fills dst with chi-squared distributed pseudo-random float64s.
+func (c *ChiSquared[S]) Fill(dst []float64) {
+	c.g.Fill(dst)
+}
@@ -0,0 +1,62 @@
+package dist
+
+import (
+	"fmt"
+)
+
+// An InverseCDF samples a distribution by inversion, quantile(U) for a
+// uniform U. U is from Float64full, which includes all the floats in [0, 1),
+// so the probabilities below 2^-53 of the lower tail are reachable. The
+// rounding variants, like RandomReal, return 1 with probability 2^-54,
+// which is quantile(1) = +Inf for an unbounded distribution.
+//
+// Near 1 the floats are 2^-53 apart and the upper tail is sampled
+// coarsely by quantile(U). A two-sided InverseCDF chooses the tail first
+// by a random bit and then evaluates the lower quantile(U/2) or the upper
+// quantile upper(U/2) = quantile(1 - U/2), so that both tails have the full
+// relative precision.
+type InverseCDF[S Source] struct {
+	src      S
+	quantile func(p float64) float64
+	upper    func(q float64) float64
+}
+
+// NewInverseCDF returns the distribution of the quantile function using the
+// generator src. The quantile is called with p in [0, 1).
+func NewInverseCDF[S Source](quantile func(p float64) float64, src S) (*InverseCDF[S], error) {
+	if quantile == nil {
+		return nil, fmt.Errorf("%w: InverseCDF quantile nil", ErrParameter)
+	}
+	return &InverseCDF[S]{src: src, quantile: quantile}, nil
+}
+
+// NewTwoSidedInverseCDF returns the distribution of the quantile functions
+// using the generator src. upper(q) = quantile(1 - q) is the quantile of the
+// upper tail probability q, eg. -log(q) for the exponential distribution.
+// Both are called with the probabilities in [0, 1/2).
+func NewTwoSidedInverseCDF[S Source](quantile, upper func(float64) float64, src S) (*InverseCDF[S], error) {
+	if quantile == nil || upper == nil {
+		return nil, fmt.Errorf("%w: InverseCDF quantile nil", ErrParameter)
+	}
+	return &InverseCDF[S]{src: src, quantile: quantile, upper: upper}, nil
+}
+
+// Rand returns a pseudo-random float64 of the distribution.
+func (d *InverseCDF[S]) Rand() float64 {
+	if d.upper == nil {
+		return d.quantile(d.src.Float64full())
+	}
+	side := d.src.Uint64() >> 63
+	u := 0.5 * d.src.Float64full()
+	if side == 0 {
+		return d.quantile(u)
+	}
+	return d.upper(u)
+}
+
+// Fill fills dst with pseudo-random float64s of the distribution.
+func (d *InverseCDF[S]) Fill(dst []float64) {
+	for i := range dst {
+		dst[i] = d.Rand()
+	}
+}
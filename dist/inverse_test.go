@@ -0,0 +1,71 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+func TestInverseCDF(t *testing.T) {
+	x := prng.NewXosh(1)
+	a := make([]float64, rounds)
+	expQ := func(p float64) float64 { return -math.Log1p(-p) }
+	d, err := NewInverseCDF(expQ, &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Fill(a)
+	dd, lim := ksTest(a, func(x float64) float64 { return -math.Expm1(-x) })
+	if dd > lim {
+		t.Errorf("exponential KS D = %f > %f", dd, lim)
+	}
+	normQ := func(p float64) float64 { return -math.Sqrt2 * math.Erfcinv(2 * p) }
+	normU := func(q float64) float64 { return math.Sqrt2 * math.Erfcinv(2 * q) }
+	d, err = NewTwoSidedInverseCDF(normQ, normU, &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Fill(a)
+	dd, lim = ksTest(a, func(x float64) float64 { return 0.5 * math.Erfc(-x / math.Sqrt2) })
+	if dd > lim {
+		t.Errorf("two-sided normal KS D = %f > %f", dd, lim)
+	}
+}
+
+// TestInverseCDFPrecision tests that the probabilities given to the quantiles
+// have full precision near 0. The 53-bit Float64 has the low bits zero below
+// 2^-10 and the upper tail of 1 - Float64 as well.
+func TestInverseCDFPrecision(t *testing.T) {
+	r := prng.New(1)
+	var small, odd [2]float64
+	record := func(side int) func(float64) float64 {
+		return func(p float64) float64 {
+			if p < 0x1p-10 {
+				small[side]++
+				odd[side] += float64(math.Float64bits(p) & 1)
+			}
+			return p
+		}
+	}
+	d, _ := NewTwoSidedInverseCDF(record(0), record(1), &r)
+	for range 1 << 22 {
+		d.Rand()
+	}
+	for side := range 2 {
+		if f := odd[side] / small[side]; small[side] < 1000 || abs(f - 0.5) > 0.05 {
+			t.Errorf("side %d: %0.f probabilities below 2^-10, odd fraction %f", side, small[side], f)
+		}
+	}
+}
+
+func TestInverseCDFParameters(t *testing.T) {
+	x := prng.NewXoro(1)
+	if _, err := NewInverseCDF(nil, &x); !errors.Is(err, ErrParameter) {
+		t.Errorf("NewInverseCDF(nil): %v", err)
+	}
+	if _, err := NewTwoSidedInverseCDF(math.Log, nil, &x); !errors.Is(err, ErrParameter) {
+		t.Errorf("NewTwoSidedInverseCDF(log, nil): %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package dist
+
+import (
+	"math"
+)
+
+// A MultiNormal is a multivariate normal distribution with a mean vector μ
+// and a covariance matrix Σ. A sample is x = μ + B z, where z is a vector of
+// standard normal variates and B B^T = Σ.
+//
+// B is the Cholesky factor of Σ. If Σ is only positive semi-definite, B is
+// from the LDL^T factorization of Σ with diagonal pivoting, B = P^T L D^(1/2),
+// with as many columns as the numerical rank of Σ.
+type MultiNormal[S Source] struct {
+	src  S
+	n    int
+	rank int
+	mean []float64
+	b    []float64 // n x rank, row-major
+	row  []int     // number of nonzero leading columns of the rows of b
+	z    []float64
+}
+
+// NewMultiNormal returns a multivariate normal distribution with the mean
+// and the covariance matrix cov using the generator src. cov is an n x n
+// row-major matrix, n = len(mean). Only the lower triangle of cov is used.
+// cov must be positive semi-definite within rounding errors.
+func NewMultiNormal[S Source](mean, cov []float64, src S) (*MultiNormal[S], error) {
+	n := len(mean)
+	if n == 0 || len(cov) != n * n {
+		return nil, paramError("MultiNormal", "covariance size", float64(len(cov)))
+	}
+	for _, x := range mean {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, paramError("MultiNormal", "mean", x)
+		}
+	}
+	for i := range n {
+		for j := range i + 1 {
+			c := cov[i*n+j]
+			if math.IsNaN(c) || math.IsInf(c, 0) || i == j && c < 0 {
+				return nil, paramError("MultiNormal", "covariance", c)
+			}
+		}
+	}
+	maxDiag := 0.0
+	for i := range n {
+		maxDiag = max(maxDiag, cov[i*n+i])
+	}
+	tol := float64(n) * 0x1p-52 * maxDiag
+	m := &MultiNormal[S]{src: src, n: n, mean: append([]float64(nil), mean...)}
+	if !m.cholesky(cov, tol) {
+		if res := m.pivotedLDL(cov, tol); res != 0 {
+			return nil, paramError("MultiNormal", "covariance residual", res)
+		}
+	}
+	m.z = make([]float64, m.rank)
+	return m, nil
+}
+
+// cholesky sets b to the Cholesky factor of cov. It returns false if cov
+// is not numerically positive definite, a pivot is not above tol.
+func (m *MultiNormal[S]) cholesky(cov []float64, tol float64) bool {
+	n := m.n
+	b := make([]float64, n * n)
+	for j := range n {
+		d := cov[j*n+j]
+		for k := range j {
+			d -= b[j*n+k] * b[j*n+k]
+		}
+		if !(d > tol) {
+			return false
+		}
+		d = math.Sqrt(d)
+		b[j*n+j] = d
+		for i := j + 1; i < n; i++ {
+			s := cov[i*n+j]
+			for k := range j {
+				s -= b[i*n+k] * b[j*n+k]
+			}
+			b[i*n+j] = s / d
+		}
+	}
+	m.b, m.rank = b, n
+	m.row = make([]int, n)
+	for i := range n {
+		m.row[i] = i + 1
+	}
+	return true
+}
+
+// pivotedLDL sets b to P^T L D^(1/2) of the LDL^T factorization of the
+// positive semi-definite cov with diagonal pivoting. The factorization stops
+// when the remaining diagonal is not above tol, n ε max(Σ_ii). If the
+// remaining Schur complement is not negligible, pivotedLDL returns its
+// largest element, and otherwise 0.
+func (m *MultiNormal[S]) pivotedLDL(cov []float64, tol float64) float64 {
+	n := m.n
+	a := make([]float64, n * n) // the Schur complement, symmetric
+	for i := range n {
+		for j := range i + 1 {
+			a[i*n+j], a[j*n+i] = cov[i*n+j], cov[i*n+j]
+		}
+	}
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	b := make([]float64, n * n) // the columns of L D^(1/2) in the pivot order
+	r := 0
+	for ; r < n; r++ {
+		p := r
+		for i := r + 1; i < n; i++ {
+			if a[perm[i]*n+perm[i]] > a[perm[p]*n+perm[p]] {
+				p = i
+			}
+		}
+		perm[r], perm[p] = perm[p], perm[r]
+		q := perm[r]
+		d := a[q*n+q]
+		if d <= tol {
+			break
+		}
+		s := math.Sqrt(d)
+		for i := r; i < n; i++ {
+			b[perm[i]*n+r] = a[perm[i]*n+q] / s
+		}
+		for i := r + 1; i < n; i++ {
+			pi := perm[i]
+			for j := r + 1; j <= i; j++ {
+				pj := perm[j]
+				a[pi*n+pj] -= b[pi*n+r] * b[pj*n+r]
+				a[pj*n+pi] = a[pi*n+pj]
+			}
+		}
+	}
+	for i := r; i < n; i++ {
+		for j := r; j < n; j++ {
+			if x := a[perm[i]*n+perm[j]]; math.Abs(x) > tol {
+				return x
+			}
+		}
+	}
+	m.rank = r
+	m.b = make([]float64, n * r)
+	m.row = make([]int, n)
+	for i := range n {
+		copy(m.b[i*r:(i+1)*r], b[i*n:i*n+r])
+	}
+	for i, pi := range perm {
+		m.row[pi] = min(i + 1, r)
+	}
+	return 0
+}
+
+// WithSource returns a MultiNormal sharing the mean and the factorization
+// of m using the generator src, eg. for a worker with a generator of its own.
+func (m *MultiNormal[S]) WithSource(src S) *MultiNormal[S] {
+	c := *m
+	c.src = src
+	c.z = make([]float64, m.rank)
+	return &c
+}
+
+// Dim returns the dimension of m.
+func (m *MultiNormal[S]) Dim() int { return m.n }
+
+// Rank returns the numerical rank of the covariance matrix of m.
+func (m *MultiNormal[S]) Rank() int { return m.rank }
+
+// Sample sets dst to a pseudo-random vector of m. Sample panics if
+// len(dst) != m.Dim().
+func (m *MultiNormal[S]) Sample(dst []float64) {
+	if len(dst) != m.n {
+		panic("invalid argument to Sample")
+	}
+	z := m.z
+	for k := range z {
+		z[k] = m.src.NormFloat64()
+	}
+	r := m.rank
+	for i := range dst {
+		b := m.b[i*r : i*r+m.row[i]]
+		s := m.mean[i]
+		for k, x := range b {
+			s += x * z[k]
+		}
+		dst[i] = s
+	}
+}
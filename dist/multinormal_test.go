@@ -0,0 +1,115 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+// testMoments tests the sample mean and covariance of m against mean and cov
+// within 5 standard errors.
+func testMoments[S Source](t *testing.T, name string, m *MultiNormal[S], mean, cov []float64) {
+	const rounds = 200000
+	n := m.Dim()
+	sum := make([]float64, n)
+	prod := make([]float64, n * n)
+	x := make([]float64, n)
+	for range rounds {
+		m.Sample(x)
+		for i := range n {
+			d := x[i] - mean[i]
+			sum[i] += d
+			for j := range n {
+				prod[i*n+j] += d * (x[j] - mean[j])
+			}
+		}
+	}
+	for i := range n {
+		if se := math.Sqrt(cov[i*n+i] / rounds); abs(sum[i] / rounds) > 5 * se + 1e-12 {
+			t.Errorf("%s: mean %d = %g, want %g", name, i, mean[i] + sum[i] / rounds, mean[i])
+		}
+		for j := range n {
+			c := cov[i*n+j]
+			se := math.Sqrt((cov[i*n+i] * cov[j*n+j] + c * c) / rounds)
+			if got := prod[i*n+j] / rounds; abs(got - c) > 5 * se + 1e-12 {
+				t.Errorf("%s: cov %d,%d = %g, want %g", name, i, j, got, c)
+			}
+		}
+	}
+}
+
+func TestMultiNormal(t *testing.T) {
+	x := prng.NewXosh(1)
+	mean := []float64{1, -2, 1e3}
+	cov := []float64{
+		4, 1.2, -0.5,
+		1.2, 1, 0.3,
+		-0.5, 0.3, 1,
+	}
+	m, err := NewMultiNormal(mean, cov, &x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Rank() != 3 {
+		t.Errorf("rank %d, want 3", m.Rank())
+	}
+	testMoments(t, "positive definite", m, mean, cov)
+	y := prng.NewXosh(2)
+	testMoments(t, "WithSource", m.WithSource(&y), mean, cov)
+}
+
+func TestMultiNormalSemiDefinite(t *testing.T) {
+	r := prng.New(1)
+	// x3 = x1 - 2 x2 and x4 = 0.
+	mean := []float64{0, 5, 0, 3}
+	cov := []float64{
+		2, 0.5, 1, 0,
+		0.5, 1, -1.5, 0,
+		1, -1.5, 4, 0,
+		0, 0, 0, 0,
+	}
+	m, err := NewMultiNormal(mean, cov, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Rank() != 2 {
+		t.Errorf("rank %d, want 2", m.Rank())
+	}
+	testMoments(t, "semi-definite", m, mean, cov)
+	v := make([]float64, 4)
+	for range 1000 {
+		m.Sample(v)
+		if abs(v[2] - (v[0] - 2 * (v[1] - 5))) > 1e-12 || v[3] != 3 {
+			t.Fatalf("sample %v off the subspace", v)
+		}
+	}
+	z, err := NewMultiNormal([]float64{1, 2}, []float64{0, 0, 0, 0}, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z.Sample(v[:2])
+	if v[0] != 1 || v[1] != 2 {
+		t.Errorf("zero covariance sample %v", v[:2])
+	}
+}
+
+func TestMultiNormalParameters(t *testing.T) {
+	x := prng.NewMCG(1)
+	for _, c := range []struct {
+		mean, cov []float64
+	}{
+		{nil, nil},
+		{[]float64{0, 0}, []float64{1, 0, 1}},
+		{[]float64{0, math.NaN()}, []float64{1, 0, 0, 1}},
+		{[]float64{0, 0}, []float64{1, 0, math.Inf(1), 1}},
+		{[]float64{0, 0}, []float64{-1, 0, 0, 1}},
+		{[]float64{0, 0}, []float64{1, 2, 2, 1}},
+		{[]float64{0, 0, 0}, []float64{1, 0, 0, 1, 1, 0, 1, -1, 1}},
+	} {
+		if _, err := NewMultiNormal(c.mean, c.cov, &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewMultiNormal(%v, %v): %v", c.mean, c.cov, err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package dist
+
+import (
+	"math"
+)
+
+// Discrete distributions are sampled by inversion for small means and by
+// transformed rejection for large means. Both run in O(1) expected time:
+// the inversion needs at most ~ mean + 1 steps below the limit.
+const inversionLimit = 10
+
+// maxFloatInt is the largest float64 with all the integers below it exact.
+const maxFloatInt = 1 << 53
+
+// A Poisson is a Poisson distribution with mean λ,
+// probability λ^k e^-λ / k! for k = 0, 1, ...
+type Poisson[S Source] struct {
+	src  S
+	mean float64
+
+	expMean float64 // e^-λ for inversion
+
+	// PTRS constants
+	logNorm  float64 // log sqrt(2π λ)
+	a, b     float64
+	invAlpha float64 // log(1/α)
+	vr       float64
+}
+
+// NewPoisson returns a Poisson distribution with the mean using the generator
+// src. The mean must be in [0, 2^53].
+func NewPoisson[S Source](mean float64, src S) (*Poisson[S], error) {
+	if !(mean >= 0 && mean <= maxFloatInt) {
+		return nil, paramError("Poisson", "mean", mean)
+	}
+	p := &Poisson[S]{src: src, mean: mean}
+	p.expMean = math.Exp(-mean)
+	if mean >= inversionLimit {
+		smu := math.Sqrt(mean)
+		p.logNorm = 0.5 * math.Log(2 * math.Pi * mean)
+		p.b = 0.931 + 2.53 * smu
+		p.a = -0.059 + 0.02483 * p.b
+		p.invAlpha = math.Log(1.1239 + 1.1328 / (p.b - 3.4))
+		p.vr = 0.9277 - 3.6224 / (p.b - 2)
+	}
+	return p, nil
+}
+
+// Mean returns the mean of p.
+func (p *Poisson[S]) Mean() float64 { return p.mean }
+
+// Rand returns a Poisson distributed pseudo-random uint64.
+func (p *Poisson[S]) Rand() uint64 {
+	if p.mean < inversionLimit {
+		return p.inversion()
+	}
+	return p.ptrs()
+}
+
+// inversion returns a Poisson variate by sequential search from 0.
+// If the cumulative probability rounds below u, the search is restarted.
+func (p *Poisson[S]) inversion() uint64 {
+	for {
+		u := p.src.Float64()
+		prob := p.expMean
+		cum := prob
+		for k := uint64(0); k < 1000; k++ {
+			if u < cum {
+				return k
+			}
+			prob *= p.mean / float64(k + 1)
+			cum += prob
+		}
+	}
+}
+
+// ptrs returns a Poisson variate by Hörmann's transformed rejection with
+// squeeze PTRS, The transformed rejection method for generating Poisson
+// random variables, https://doi.org/10.1016/0167-6687(93)90997-4.
+func (p *Poisson[S]) ptrs() uint64 {
+	for {
+		u := p.src.Float64() - 0.5
+		v := p.src.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2 * p.a / us + p.b) * u + p.mean + 0.43)
+		if us >= 0.07 && v <= p.vr {               // ~89% of cases
+			return uint64(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		if math.Log(v) + p.invAlpha - math.Log(p.a / (us * us) + p.b) <= p.logPmf(k) {
+			return uint64(k)
+		}
+	}
+}
+
+// logPmf returns log(λ^k e^-λ / k!) by Stirling's formula with the tail
+// fc(k) as in BTRD. -λ + k log λ - log k! has terms of the size λ log λ,
+// which cancel to O(log λ), so it is written as
+// d - (k + 1/2) log(1 + d/λ) - log sqrt(2πλ) - fc(k), d = k + 1 - λ,
+// where the first two terms cancel only to O(d²/λ) and log1p keeps the
+// precision of d/λ.
+func (p *Poisson[S]) logPmf(k float64) float64 {
+	d := k + 1 - p.mean
+	return d - (k + 0.5) * math.Log1p(d / p.mean) - p.logNorm - stirlingTail(k)
+}
+
+// Fill fills dst with Poisson distributed pseudo-random uint64s.
+func (p *Poisson[S]) Fill(dst []uint64) {
+	for i := range dst {
+		dst[i] = p.Rand()
+	}
+}
@@ -0,0 +1,131 @@
+package dist
+
+import (
+	"math"
+)
+
+// A Stable is an α-stable distribution S(α, β, γ, δ; 0) with the stability
+// index α, skewness β, scale γ and location δ in Nolan's parameterization 0,
+// which is continuous in all the parameters. A variate is γ (Z - β tan(πα/2)) + δ
+// for α != 1 and γ Z + δ for α = 1, Z ~ S(α, β, 1, 0; 1) the standard stable
+// variate in the parameterization 1 of Samorodnitsky & Taqqu. The location of
+// the parameterization 1 is δ - βγ tan(πα/2) for α != 1 and
+// δ - β (2/π) γ log γ for α = 1.
+//
+// S(2, 0, γ, δ; 0) is N(δ, 2γ²), S(1, 0, γ, δ; 0) is Cauchy(δ, γ) and
+// S(1/2, 1, γ, δ; 0) is Lévy(δ - γ, γ).
+type Stable[S Source] struct {
+	src   S
+	alpha float64
+	beta  float64
+	scale float64
+	loc   float64
+
+	zeta  float64 // β tan(πα/2)
+	theta float64 // atan(ζ) / α
+	lcos  float64 // log cos(αθ) = -log(1 + ζ²) / 2
+}
+
+// NewStable returns an α-stable distribution with the parameters alpha, beta,
+// scale and loc using the generator src. alpha must be in (0, 2], beta in
+// [-1, 1], scale positive and loc finite.
+func NewStable[S Source](alpha, beta, scale, loc float64, src S) (*Stable[S], error) {
+	if !(alpha > 0 && alpha <= 2) {
+		return nil, paramError("Stable", "alpha", alpha)
+	}
+	if !(beta >= -1 && beta <= 1) {
+		return nil, paramError("Stable", "beta", beta)
+	}
+	if !positive(scale) {
+		return nil, paramError("Stable", "scale", scale)
+	}
+	if math.IsNaN(loc) || math.IsInf(loc, 0) {
+		return nil, paramError("Stable", "loc", loc)
+	}
+	d := &Stable[S]{src: src, alpha: alpha, beta: beta, scale: scale, loc: loc}
+	if alpha != 1 && alpha != 2 {
+		// tan(πα/2) = -1/tan(π(α-1)/2), exact near α = 1.
+		d.zeta = -beta / math.Tan(math.Pi / 2 * (alpha - 1))
+	}
+	d.theta = math.Atan(d.zeta) / alpha
+	d.lcos = -math.Log1p(d.zeta * d.zeta) / 2
+	return d, nil
+}
+
+// Alpha returns the stability index α of d.
+func (d *Stable[S]) Alpha() float64 { return d.alpha }
+
+// Beta returns the skewness β of d.
+func (d *Stable[S]) Beta() float64 { return d.beta }
+
+// Scale returns the scale γ of d.
+func (d *Stable[S]) Scale() float64 { return d.scale }
+
+// Loc returns the location δ of d in the parameterization 0.
+func (d *Stable[S]) Loc() float64 { return d.loc }
+
+// Rand returns an α-stable pseudo-random float64.
+// Rand uses Chambers, Mallows & Stuck: A Method for Simulating Stable Random
+// Variables, https://doi.org/10.2307/2285309, with the formulas of Weron:
+// On the Chambers-Mallows-Stuck method for simulating skewed stable random
+// variables, https://doi.org/10.1016/0167-7152(95)00113-1. V is uniform on
+// (-π/2, π/2) and W exponential.
+func (d *Stable[S]) Rand() float64 {
+	// V from an open uniform, so that cos V > 0.
+	v := math.Pi * (float64(d.src.Uint64() >> 11) + 0.5) * 0x1p-53 - math.Pi / 2
+	w := d.src.ExpFloat64()
+	for w == 0 {
+		w = d.src.ExpFloat64()
+	}
+	return d.scale * d.standard(v, w) + d.loc
+}
+
+// standard returns the standard variate of the parameterization 0 of V and W.
+func (d *Stable[S]) standard(v, w float64) float64 {
+	alpha, beta := d.alpha, d.beta
+	if alpha == 1 {
+		h := math.Pi / 2 + beta * v
+		return 2 / math.Pi * (h * math.Tan(v) - beta * math.Log(math.Pi / 2 * w * math.Cos(v) / h))
+	}
+	if math.Cos(alpha * v) > 0 {
+		return d.nearOne(v, w)
+	}
+	return d.direct(v, w)
+}
+
+// direct returns Z - ζ with Weron's formula of the parameterization 1,
+// Z = sin(α(V+θ)) / (cos(αθ) cos V)^(1/α) (cos(V - α(V+θ)) / W)^((1-α)/α).
+// Z - ζ cancels when ζ is large, α near 1 and β != 0.
+func (d *Stable[S]) direct(v, w float64) float64 {
+	alpha, theta := d.alpha, d.theta
+	t := math.Cos(v - alpha * (v + theta)) / w
+	z := math.Sin(alpha * (v + theta)) *
+		math.Exp(-(d.lcos + math.Log(math.Cos(v))) / alpha + (1 - alpha) / alpha * math.Log(t))
+	return z - d.zeta
+}
+
+// nearOne returns Z - ζ for cos αV > 0 without the cancellation near α = 1.
+// With ε = α - 1 the direct formula is Z = tan(αV) e^E + ζ e^E, where
+//
+//	E = log(cos(αV) / cos V) + ε/α (log cos V - log T + log cos αθ),
+//	cos(αV) / cos V - 1 = -2 sin²(εV/2) - tan V sin(εV),
+//
+// and T = cos(V - α(V+θ)) / W = cos(αθ) (cos εV - ζ sin εV) / W. So
+// Z - ζ = tan(αV) e^E + ζ (e^E - 1) and E is computed to a relative precision
+// as ε → 0.
+func (d *Stable[S]) nearOne(v, w float64) float64 {
+	alpha := d.alpha
+	eps := alpha - 1
+	s := math.Sin(eps * v / 2)
+	sin := math.Sin(eps * v)
+	e := math.Log1p(-2 * s * s - math.Tan(v) * sin) +
+		eps / alpha * (math.Log(math.Cos(v) * w) - math.Log(1 - 2 * s * s - d.zeta * sin))
+	return math.Tan(alpha * v) * math.Exp(e) + d.zeta * math.Expm1(e)
+}
+
+// Fill fills dst with α-stable pseudo-random float64s.
+func (d *Stable[S]) Fill(dst []float64) {
+	for i := range dst {
+		dst[i] = d.Rand()
+	}
+}
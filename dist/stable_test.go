@@ -0,0 +1,116 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+func TestStable(t *testing.T) {
+	x := prng.NewXosh(1)
+	a := make([]float64, rounds)
+	for _, c := range []struct {
+		alpha, beta, scale, loc float64
+		name                    string
+		cdf                     func(float64) float64
+	}{
+		{2, 0.3, 1.5, -1, "Gaussian", func(x float64) float64 {
+			return 0.5 * math.Erfc(-(x + 1) / (1.5 * 2))
+		}},
+		{1, 0, 2, 1, "Cauchy", func(x float64) float64 {
+			return 0.5 + math.Atan((x - 1) / 2) / math.Pi
+		}},
+		{0.5, 1, 1.5, 3, "Lévy", func(x float64) float64 {
+			if x <= 1.5 {
+				return 0
+			}
+			return math.Erfc(math.Sqrt(1.5 / (2 * (x - 1.5))))
+		}},
+		{0.5, -1, 1, 0, "Lévy mirrored", func(x float64) float64 {
+			if x >= 1 {
+				return 1
+			}
+			return 1 - math.Erfc(math.Sqrt(1 / (2 * (1 - x))))
+		}},
+	} {
+		d, err := NewStable(c.alpha, c.beta, c.scale, c.loc, &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Fill(a)
+		dd, lim := ksTest(a, c.cdf)
+		t.Logf("Stable %s KS D = %f", c.name, dd)
+		if dd > lim {
+			t.Errorf("Stable %s KS D = %f > %f", c.name, dd, lim)
+		}
+	}
+}
+
+// TestStableNearOne tests that the standard variates of V and W are
+// continuous in α at α = 1.
+func TestStableNearOne(t *testing.T) {
+	x := prng.NewXoro(1)
+	for _, beta := range []float64{0, 0.5, -0.9, 1, -1} {
+		one, _ := NewStable(1, beta, 1, 0, &x)
+		for _, eps := range []float64{1e-3, 1e-6, 1e-9, 1e-12, 1e-15} {
+			for _, alpha := range []float64{1 - eps, 1 + eps} {
+				d, _ := NewStable(alpha, beta, 1, 0, &x)
+				maxDiff := 0.0
+				for range 10000 {
+					v := math.Pi * (x.Float64() - 0.5)
+					w := x.ExpFloat64()
+					if v == -math.Pi / 2 || w == 0 {
+						continue
+					}
+					z1, z := one.standard(v, w), d.standard(v, w)
+					diff := abs(z - z1) / (1 + z1 * z1)
+					maxDiff = max(maxDiff, diff)
+				}
+				// The variates change by O(ε log ε). With β = ±1 the rounding
+				// of V near -βπ/2 is amplified the same way in both formulas.
+				if maxDiff > 1e4 * eps * (1 - math.Log(eps)) {
+					t.Errorf("Stable(1%+g, %g): max difference %g to α = 1", alpha - 1, beta, maxDiff)
+				}
+			}
+		}
+	}
+}
+
+// TestStableFormulas tests the direct and the near one formulas against
+// each other.
+func TestStableFormulas(t *testing.T) {
+	x := prng.NewMCG(1)
+	for _, alpha := range []float64{0.3, 0.8, 1.2, 1.7} {
+		for _, beta := range []float64{0, 0.5, -1} {
+			d, _ := NewStable(alpha, beta, 1, 0, &x)
+			for range 10000 {
+				v := math.Pi * (x.Float64() - 0.5)
+				w := x.ExpFloat64()
+				if math.Cos(alpha * v) <= 0 || v == -math.Pi / 2 || w == 0 {
+					continue
+				}
+				z1, z2 := d.direct(v, w), d.nearOne(v, w)
+				if abs(z1 - z2) > 1e-9 * (1 + abs(z1) + abs(d.zeta)) {
+					t.Fatalf("Stable(%g, %g) V %g W %g: direct %g, near one %g", alpha, beta, v, w, z1, z2)
+				}
+			}
+		}
+	}
+}
+
+func TestStableParameters(t *testing.T) {
+	x := prng.New(1)
+	nan, inf := math.NaN(), math.Inf(1)
+	for _, c := range [][4]float64{
+		{0, 0, 1, 0}, {2.01, 0, 1, 0}, {nan, 0, 1, 0},
+		{1, 1.01, 1, 0}, {1, nan, 1, 0},
+		{1, 0, 0, 0}, {1, 0, inf, 0},
+		{1, 0, 1, inf}, {1, 0, 1, nan},
+	} {
+		if _, err := NewStable(c[0], c[1], c[2], c[3], &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewStable%v: %v", c, err)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package dist
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// The sampling methods of TruncNormal for the standardized interval [α, β].
+const (
+	truncNormal  = iota // rejection from N(0, 1)
+	truncTable          // Chopin's equal-area rectangles
+	truncUniform        // uniform proposal on [α, β]
+	truncExp            // Robert's translated exponential proposal, 0 <= α
+)
+
+// Chopin's table covers [tableMin, tableMax] of exp(-x²/2) with rectangles
+// of the area tableArea. The tail beyond tableMax has the exponential
+// envelope exp(-tableMax²/2 - tableMax (x - tableMax)) of the same area.
+const (
+	tableMax   = 3.48672170399
+	tableBelow = -2   // the table is built down to below tableBelow
+	tableKmin  = 5    // the least number of cells for the table method
+)
+
+var tableArea = math.Exp(-tableMax * tableMax / 2) / tableMax
+
+// A chopinCell is the rectangle [x, x+dx] × [0, yu] of Chopin's table. The
+// density exp(-z²/2) is yl at least and yu at most on [x, x+dx].
+type chopinCell struct {
+	x, dx, yu, yl float64
+}
+
+// chopinTable is Chopin's table, built downward from tableMax. The
+// rectangles right of 0 have the height exp(-x²/2) at the left end, found
+// by Newton's method, the one over 0 the height 1 and those left of 0 the
+// height at the right end.
+var chopinTable = func() (t []chopinCell) {
+	f := func(x float64) float64 { return math.Exp(-x * x / 2) }
+	for b := tableMax; b >= tableBelow; {
+		var c chopinCell
+		switch {
+		case b <= 0:
+			c.yu, c.yl = f(b), f(b - tableArea / f(b))
+		case b <= tableArea:
+			c.yu, c.yl = 1, min(f(b), f(b - tableArea))
+		default:
+			w := tableArea / f(b)
+			for range 8 {
+				g := w * f(b - w) - tableArea
+				w -= g / (f(b - w) * (1 + w * (b - w)))
+			}
+			c.yu, c.yl = f(b - w), f(b)
+		}
+		c.dx = tableArea / c.yu
+		c.x = b - c.dx
+		t = append(t, c)
+		b = c.x
+	}
+	slices.Reverse(t)
+	return t
+}()
+
+// tableMin is the lower end of Chopin's table.
+var tableMin = chopinTable[0].x
+
+// A TruncNormal is a normal distribution N(μ, σ²) truncated to the
+// interval [a, b].
+type TruncNormal[S Source] struct {
+	src         S
+	mu, sigma   float64
+	a, b        float64
+	alpha, beta float64 // the standardized interval, alpha + beta >= 0
+	flip        bool    // the interval is mirrored
+	method      int
+	lambda      float64 // rate of the exponential proposal
+	shift       float64 // alpha - lambda
+	ka, kb      int     // the cells of alpha and beta in chopinTable
+}
+
+// NewTruncNormal returns a normal distribution with the mean mu and standard
+// deviation sigma truncated to [a, b] using the generator src. mu must be
+// finite, sigma positive and a < b. a may be -Inf and b +Inf.
+func NewTruncNormal[S Source](mu, sigma, a, b float64, src S) (*TruncNormal[S], error) {
+	if math.IsNaN(mu) || math.IsInf(mu, 0) {
+		return nil, paramError("TruncNormal", "mu", mu)
+	}
+	if !positive(sigma) {
+		return nil, paramError("TruncNormal", "sigma", sigma)
+	}
+	if !(a < b) || math.IsInf(a, 1) || math.IsInf(b, -1) {
+		return nil, paramError("TruncNormal", "interval width", b - a)
+	}
+	d := &TruncNormal[S]{src: src, mu: mu, sigma: sigma, a: a, b: b}
+	alpha, beta := (a - mu) / sigma, (b - mu) / sigma
+	if alpha + beta < 0 {
+		alpha, beta, d.flip = -beta, -alpha, true
+	}
+	d.alpha, d.beta = alpha, beta
+	switch {
+	case alpha < tableMin:
+		// The interval covers [-2, 2] of probability 0.95.
+		d.method = truncNormal
+	case alpha < tableMax:
+		d.ka = sort.Search(len(chopinTable), func(k int) bool { return chopinTable[k].x > alpha }) - 1
+		d.kb = len(chopinTable) // the tail
+		if beta < tableMax {
+			d.kb = sort.Search(len(chopinTable), func(k int) bool { return chopinTable[k].x >= beta }) - 1
+		}
+		if d.kb - d.ka >= tableKmin {
+			d.method = truncTable
+			break
+		}
+		fallthrough
+	default:
+		d.robert()
+	}
+	return d, nil
+}
+
+// robert sets the method to Robert's uniform or exponential proposal.
+func (d *TruncNormal[S]) robert() {
+	alpha := d.alpha
+	if alpha < 0 || d.beta - alpha < uniformWidth(alpha) {
+		d.method = truncUniform
+		return
+	}
+	d.method = truncExp
+	h := math.Hypot(alpha, 2)
+	d.lambda = (alpha + h) / 2
+	d.shift = -2 / (alpha + h)
+}
+
+// uniformWidth returns the interval width below which the uniform proposal
+// on [α, β] has a better acceptance rate than the exponential proposal,
+// 2 √e / (α + √(α²+4)) exp((α² - α √(α²+4)) / 4) by Robert.
+func uniformWidth(alpha float64) float64 {
+	h := math.Hypot(alpha, 2)
+	return 2 * math.Sqrt(math.E) / (alpha + h) * math.Exp(-alpha / (alpha + h))
+}
+
+// Mu returns the mean of the normal distribution before truncation.
+func (d *TruncNormal[S]) Mu() float64 { return d.mu }
+
+// Sigma returns the standard deviation of the normal distribution
+// before truncation.
+func (d *TruncNormal[S]) Sigma() float64 { return d.sigma }
+
+// Bounds returns the truncation interval [a, b].
+func (d *TruncNormal[S]) Bounds() (a, b float64) { return d.a, d.b }
+
+// Rand returns a truncated normal pseudo-random float64 in [a, b].
+// The interval is standardized to [α, β] and mirrored so that α + β >= 0.
+// For α below -2 the variates of NormFloat64 outside [α, β] are rejected.
+// For α in [-2, 3.49) Rand uses the table method of Chopin: Fast simulation
+// of truncated Gaussian distributions, https://arxiv.org/abs/1201.6140,
+// which draws from about 3700 equal-area rectangles covering the density
+// on [α, β] and an exponential envelope beyond 3.49. For α >= 3.49, and
+// for intervals narrower than 5 rectangles, Rand uses the rejection samplers
+// of Robert: Simulation of truncated normal variables,
+// https://arxiv.org/abs/0907.4010, a uniform proposal for narrow intervals
+// and the proposal α + E/λ, E exponential, else. Its acceptance rate is
+// above 0.75 for all α and tends to 1 as α grows, so intervals like
+// [30σ, ∞) are sampled as fast as [0, ∞).
+func (d *TruncNormal[S]) Rand() float64 {
+	z := d.standard()
+	if d.flip {
+		z = -z
+	}
+	x := d.mu + d.sigma * z
+	return min(max(x, d.a), d.b) // rounding of μ + σz
+}
+
+// standard returns a standard normal variate truncated to [alpha, beta].
+func (d *TruncNormal[S]) standard() float64 {
+	alpha, beta := d.alpha, d.beta
+	switch d.method {
+	case truncNormal:
+		for {
+			z := d.src.NormFloat64()
+			if z >= alpha && z <= beta {
+				return z
+			}
+		}
+	case truncTable:
+		e := exact[S]{d.src}
+		m := uint64(d.kb - d.ka + 1)
+		for {
+			k := d.ka + int(e.uniform(m))
+			if k == len(chopinTable) {
+				// z = tableMax + E/tableMax under the exponential envelope.
+				z := d.src.ExpFloat64() / tableMax
+				if z <= beta - tableMax && d.src.ExpFloat64() >= z * z / 2 {
+					return tableMax + z
+				}
+				continue
+			}
+			c := &chopinTable[k]
+			if k == d.ka || k == d.kb {
+				// The end cells are cut to [α, β].
+				z := c.x + c.dx * d.src.Float64()
+				if z < alpha || z > beta {
+					continue
+				}
+				if y := c.yu * d.src.Float64(); y < c.yl || y < math.Exp(-z * z / 2) {
+					return z
+				}
+				continue
+			}
+			u := d.src.Float64()
+			y := c.yu * u
+			if y < c.yl {
+				// Under the density, u yu/yl is uniform on [0, 1).
+				return c.x + c.dx * (y / c.yl)
+			}
+			z := c.x + c.dx * d.src.Float64()
+			if y < math.Exp(-z * z / 2) {
+				return z
+			}
+		}
+	case truncUniform:
+		// The density relative to its maximum on [α, β]: exp(-z²/2) if 0 is
+		// inside, else exp((α² - z²)/2) = exp(-(z-α)(z+α)/2).
+		w := beta - alpha
+		for {
+			z := alpha + w * d.src.Float64()
+			var logRatio float64
+			if alpha < 0 {
+				logRatio = -z * z / 2
+			} else {
+				logRatio = -(z - alpha) * (z + alpha) / 2
+			}
+			if -d.src.ExpFloat64() <= logRatio {
+				return z
+			}
+		}
+	default:
+		// z = α + E/λ accepted with probability exp(-(z-λ)²/2).
+		for {
+			e := d.src.ExpFloat64() / d.lambda
+			z := alpha + e
+			if z > beta {
+				continue
+			}
+			y := d.shift + e
+			if d.src.ExpFloat64() >= y * y / 2 {
+				return z
+			}
+		}
+	}
+}
+
+// Fill fills dst with truncated normal pseudo-random float64s.
+func (d *TruncNormal[S]) Fill(dst []float64) {
+	for i := range dst {
+		dst[i] = d.Rand()
+	}
+}
@@ -0,0 +1,159 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+// normQ returns the upper tail probability of N(0, 1) at x.
+func normQ(x float64) float64 {
+	return 0.5 * math.Erfc(x / math.Sqrt2)
+}
+
+func TestTruncNormal(t *testing.T) {
+	x := prng.NewXoro(1)
+	a := make([]float64, rounds)
+	for _, c := range [][4]float64{
+		{0, 1, -1, 2},
+		{0, 1, -0.1, 0.1},
+		{0, 1, math.Inf(-1), math.Inf(1)},
+		{0, 1, 0.3, math.Inf(1)},
+		{0, 1, 0.2, 1.5},
+		{0, 1, 2, 5},
+		{0, 1, 2, 2.1},
+		{0, 1, 30, math.Inf(1)},
+		{0, 1, 30, 30.01},
+		{0, 1, 8, 9},
+		{0, 1, -1.9, -0.5},
+		{0, 1, -1.5, 3},
+		{0, 1, 0.5, 4},
+		{0, 1, 1, math.Inf(1)},
+		{0, 1, 3.4, 3.6},
+		{10, 2, math.Inf(-1), 0},
+		{-3, 0.5, -100, -12},
+	} {
+		mu, sigma, lo, hi := c[0], c[1], c[2], c[3]
+		d, err := NewTruncNormal(mu, sigma, lo, hi, &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Fill(a)
+		for _, v := range a {
+			if !(v >= lo && v <= hi) {
+				t.Fatalf("TruncNormal%v = %g", c, v)
+			}
+		}
+		alpha, beta := (lo - mu) / sigma, (hi - mu) / sigma
+		var cdf func(float64) float64
+		if alpha + beta > 0 {
+			// The upper tail probabilities keep the precision for α > 0.
+			qa, qb := normQ(alpha), normQ(beta)
+			cdf = func(v float64) float64 { return (qa - normQ((v - mu) / sigma)) / (qa - qb) }
+		} else {
+			pa, pb := normQ(-alpha), normQ(-beta)
+			cdf = func(v float64) float64 { return (normQ(-(v - mu) / sigma) - pa) / (pb - pa) }
+		}
+		dd, lim := ksTest(a, cdf)
+		t.Logf("TruncNormal%v KS D = %f", c, dd)
+		if dd > lim {
+			t.Errorf("TruncNormal%v KS D = %f > %f", c, dd, lim)
+		}
+	}
+}
+
+// TestChopinTable tests that the cells of Chopin's table are contiguous,
+// of equal area and bound the density.
+func TestChopinTable(t *testing.T) {
+	f := func(x float64) float64 { return math.Exp(-x * x / 2) }
+	r := prng.New(1)
+	if tableMin > tableBelow || len(chopinTable) < 3000 {
+		t.Fatalf("table of %d cells from %g", len(chopinTable), tableMin)
+	}
+	for k, c := range chopinTable {
+		b := tableMax
+		if k + 1 < len(chopinTable) {
+			b = chopinTable[k + 1].x
+		}
+		if math.Abs(c.x + c.dx - b) > 1e-12 {
+			t.Errorf("cell %d ends at %g, next at %g", k, c.x + c.dx, b)
+		}
+		if math.Abs(c.dx * c.yu / tableArea - 1) > 1e-12 {
+			t.Errorf("cell %d area %g", k, c.dx * c.yu)
+		}
+		for _, z := range []float64{c.x, c.x + c.dx / 2, c.x + c.dx} {
+			if y := f(z); y < c.yl * (1 - 1e-12) || y > c.yu * (1 + 1e-12) {
+				t.Errorf("cell %d: f(%g) = %g not in [%g, %g]", k, z, y, c.yl, c.yu)
+			}
+		}
+	}
+	for _, c := range []struct {
+		alpha, beta float64
+		method      int
+	}{
+		{-3, 3, truncNormal},
+		{-1, 2, truncTable},
+		{-2, 1, truncTable},
+		{1, math.Inf(1), truncTable},
+		{3, math.Inf(1), truncTable},
+		{3.4, 3.6, truncUniform},
+		{-0.001, 0.001, truncUniform},
+		{3.48, 3.49, truncUniform},
+		{3.5, math.Inf(1), truncExp},
+	} {
+		d, _ := NewTruncNormal(0, 1, c.alpha, c.beta, &r)
+		if d.method != c.method {
+			t.Errorf("TruncNormal(%g, %g) method %d, want %d", c.alpha, c.beta, d.method, c.method)
+		}
+	}
+}
+
+// TestTruncNormalFarTail tests α(Z - α) ~ Exp(1) for Z ~ N(0, 1) in [α, ∞),
+// as α → ∞.
+func TestTruncNormalFarTail(t *testing.T) {
+	r := prng.New(1)
+	a := make([]float64, rounds)
+	for _, alpha := range []float64{1e4, 1e100} {
+		d, err := NewTruncNormal(0, 1, alpha, math.Inf(1), &r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d.Fill(a)
+		for i, v := range a {
+			a[i] = alpha * (v - alpha)
+		}
+		if alpha > 1e10 {
+			// The variates round to α.
+			if a[0] != 0 {
+				t.Errorf("TruncNormal(%g) = %g", alpha, a[0] / alpha + alpha)
+			}
+			continue
+		}
+		dd, lim := ksTest(a, func(x float64) float64 { return -math.Expm1(-x) })
+		if dd > lim {
+			t.Errorf("TruncNormal(%g, Inf) KS D = %f > %f", alpha, dd, lim)
+		}
+	}
+}
+
+func TestTruncNormalParameters(t *testing.T) {
+	x := prng.NewXosh(1)
+	inf := math.Inf(1)
+	for _, c := range [][4]float64{
+		{math.NaN(), 1, 0, 1},
+		{inf, 1, 0, 1},
+		{0, 0, 0, 1},
+		{0, -1, 0, 1},
+		{0, 1, 1, 1},
+		{0, 1, 2, 1},
+		{0, 1, math.NaN(), 1},
+		{0, 1, inf, inf},
+		{0, 1, -inf, -inf},
+	} {
+		if _, err := NewTruncNormal(c[0], c[1], c[2], c[3], &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewTruncNormal%v: %v", c, err)
+		}
+	}
+}
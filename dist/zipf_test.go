@@ -0,0 +1,187 @@
+package dist
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"testing"
+
+	"github.com/pekkizen/prng"
+)
+
+// zipfSum returns Σ_{k=1}^n k^-s by direct summation up to 1000 and
+// Euler-Maclaurin above.
+func zipfSum(s float64, n uint64) float64 {
+	const m = 1000
+	sum := 0.0
+	for k := uint64(1); k <= min(n, m); k++ {
+		sum += math.Pow(float64(k), -s)
+	}
+	if n <= m {
+		return sum
+	}
+	a, b := float64(m), float64(n)
+	var integral float64
+	if s == 1 {
+		integral = math.Log(b / a)
+	} else {
+		integral = (math.Pow(b, 1-s) - math.Pow(a, 1-s)) / (1 - s)
+	}
+	return sum + integral + (math.Pow(b, -s) - math.Pow(a, -s)) / 2 -
+		s / 12 * (math.Pow(b, -s-1) - math.Pow(a, -s-1))
+}
+
+func TestZipf(t *testing.T) {
+	const rounds = 1000000
+	x := prng.NewXosh(1)
+	sample := make([]uint64, rounds)
+	for _, c := range []struct {
+		s float64
+		n uint64
+	}{
+		{0.5, 1}, {0.5, 10}, {1, 100}, {1.0000001, 50}, {0.99, 1000}, {1.5, 500}, {3, 1000}, {40, 5},
+	} {
+		z, err := NewZipf(c.s, c.n, &x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		z.Fill(sample)
+		pmf := make([]float64, c.n)
+		h := zipfSum(c.s, c.n)
+		for k := range pmf {
+			pmf[k] = math.Pow(float64(k+1), -c.s) / h
+		}
+		chiSquare(t, "Zipf("+ftoa(c.s)+", "+ftoa(float64(c.n))+")", sample, 1, pmf)
+	}
+}
+
+// TestZipfLarge tests the first ranks and the tail of Zipf over 2^62.
+func TestZipfLarge(t *testing.T) {
+	const rounds = 1000000
+	const cells = 30
+	r := prng.New(1)
+	sample := make([]uint64, rounds)
+	for _, s := range []float64{0.5, 1, 1.1, 2} {
+		const n = 1 << 62
+		z, err := NewZipf(s, n, &r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		z.Fill(sample)
+		odd := 0.0
+		for i, k := range sample {
+			if k < 1 || k > n {
+				t.Fatalf("Zipf(%g, 2^62) = %d", s, k)
+			}
+			if k > maxFloatInt {
+				odd += float64(k & 1)
+			}
+			sample[i] = min(k, cells)
+		}
+		h := zipfSum(s, n)
+		pmf := make([]float64, cells)
+		tail := 1.0
+		for k := range cells - 1 {
+			pmf[k] = math.Pow(float64(k+1), -s) / h
+			tail -= pmf[k]
+		}
+		pmf[cells-1] = tail
+		chiSquare(t, "Zipf("+ftoa(s)+", 2^62)", sample, 1, pmf)
+		if s == 0.5 {
+			// P(k > 2^53) = 1 - 2^-4.5 and the parity is balanced.
+			big := rounds * (1 - math.Pow(2, -4.5))
+			if f := odd / big; abs(f - 0.5) > 0.005 {
+				t.Errorf("Zipf(0.5, 2^62) odd fraction %f above 2^53", f)
+			}
+		}
+	}
+}
+
+// TestZipfTail tests the binades above 2^24, where Zipf draws k from a
+// window, and that the low bits of k are uniform there. With a float64
+// inversion only, adjacent k are not all reachable above 2^40.
+func TestZipfTail(t *testing.T) {
+	const rounds = 1000000
+	const lo, bins = 24, 64
+	r := prng.New(1)
+	sample := make([]uint64, rounds)
+	for _, c := range []struct {
+		s float64
+		n uint64
+	}{
+		{0.5, 1 << 62}, {1, 1 << 53}, {1, 1 << 62}, {1.1, 1<<62 - 12345},
+	} {
+		z, err := NewZipf(c.s, c.n, &r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		z.Fill(sample)
+		// ∫ x^-s over [a - 1/2, b - 1/2) / H(n, s)
+		h := zipfSum(c.s, c.n)
+		mass := func(a, b float64) float64 {
+			a, b = a - 0.5, b - 0.5
+			if c.s == 1 {
+				return math.Log(b / a) / h
+			}
+			return (math.Pow(b, 1-c.s) - math.Pow(a, 1-c.s)) / (1 - c.s) / h
+		}
+		top := bits.Len64(c.n) - 1
+		pmf := make([]float64, top-lo+2)
+		pmf[0] = 1 - mass(1<<lo, float64(c.n)+1)
+		for j := lo; j <= top; j++ {
+			pmf[j-lo+1] = mass(float64(uint64(1)<<j), float64(min(uint64(1)<<(j+1), c.n+1)))
+		}
+		low := make([]uint64, 0, rounds)
+		for i, k := range sample {
+			if k < 1 || k > c.n {
+				t.Fatalf("Zipf(%g, %d) = %d", c.s, c.n, k)
+			}
+			if k >= 1<<32 {
+				low = append(low, k%bins)
+			}
+			sample[i] = uint64(max(bits.Len64(k)-lo, 0))
+		}
+		name := "Zipf(" + ftoa(c.s) + ", " + ftoa(float64(c.n)) + ")"
+		chiSquare(t, name+" binades", sample, 0, pmf)
+		uniform := make([]float64, bins)
+		for k := range uniform {
+			uniform[k] = 1.0 / bins
+		}
+		chiSquare(t, name+" k mod 64", low, 0, uniform)
+	}
+}
+
+// TestZipfInvTop tests that hIntegralInvTop inverts hIntegral from hn.
+func TestZipfInvTop(t *testing.T) {
+	x := prng.NewXoro(1)
+	for _, s := range []float64{0.5, 0.999, 1, 1.001, 2, 30} {
+		for _, n := range []uint64{10, 1e6, 1 << 40, 1 << 62} {
+			z, err := NewZipf(s, n, &x)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range []float64{1e-3, 0.1, 0.5, 0.999} {
+				v := f * z.half
+				got := z.hIntegralInvTop(v)
+				want := z.hIntegralInv(z.hn - v)
+				if abs(got - want) > 1e-9 * want {
+					t.Errorf("Zipf(%g, %d): hIntegralInvTop(%g) = %g, want %g", s, n, v, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestZipfParameters(t *testing.T) {
+	x := prng.NewXoro(1)
+	for _, s := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, err := NewZipf(s, 10, &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewZipf(%g, 10): %v", s, err)
+		}
+	}
+	for _, n := range []uint64{0, 1<<62 + 1} {
+		if _, err := NewZipf(1, n, &x); !errors.Is(err, ErrParameter) {
+			t.Errorf("NewZipf(1, %d): %v", n, err)
+		}
+	}
+}
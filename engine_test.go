@@ -0,0 +1,139 @@
+package prng
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPrngEngines(t *testing.T) {
+	r, xr := New(1), NewXoro(1)
+	s, xs := NewXoshPrng(1), NewXosh(1)
+	m, xm := NewMCGPrng(1), NewMCG(1)
+	for i := 0; i < 1000; i++ {
+		if r.Uint64() != xr.Uint64() || s.Uint64() != xs.Uint64() || m.Uint64() != xm.Uint64() {
+			t.Fatalf("a Prng and its generator differ at %d", i)
+		}
+	}
+	if len(r.State()) != PrngStateSize || len(s.State()) != XoshPrngStateSize ||
+		len(m.State()) != MCGPrngStateSize {
+		t.Errorf("len(State()) != state size")
+	}
+}
+
+// TestPrngMethods tests that XoshPrng and MCGPrng have the methods of Prng.
+func TestPrngMethods(t *testing.T) {
+	p := reflect.TypeOf(&Prng{})
+	for _, q := range []reflect.Type{reflect.TypeOf(&XoshPrng{}), reflect.TypeOf(&MCGPrng{})} {
+		for i := 0; i < p.NumMethod(); i++ {
+			m := p.Method(i)
+			if n, ok := q.MethodByName(m.Name); !ok || n.Type.NumIn() != m.Type.NumIn() {
+				t.Errorf("%v has no method %s", q, m.Name)
+			}
+		}
+		if q.NumMethod() != p.NumMethod() {
+			t.Errorf("%v has %d methods, Prng %d", q, q.NumMethod(), p.NumMethod())
+		}
+	}
+}
+
+func TestNextXoshPrng(t *testing.T) {
+	s := NewOutlet(1)
+	x := s.NextXosh()
+	r := s.NextXoshPrng()
+	x.Jump()
+	if r.Uint64() != x.Uint64() {
+		t.Errorf("NextXoshPrng is not the next Xosh stream of the Outlet")
+	}
+	m := s.NextMCGPrng()
+	y := s.NextMCG()
+	m.Jump()
+	if m.Uint64() != y.Uint64() {
+		t.Errorf("NextMCGPrng is not the next MCG stream of the Outlet")
+	}
+	a := NewXoshPrngSlice(3, 1)
+	b := NewXoshPrng(1)
+	b.Jump()
+	b.Jump()
+	if a[2].Uint64() != b.Uint64() {
+		t.Errorf("NewXoshPrngSlice streams are not jumps")
+	}
+}
+
+func TestPrngBernoulli(t *testing.T) {
+	r, s, m := New(1), NewXoshPrng(1), NewMCGPrng(1)
+	xr, xs, xm := NewXoro(1), NewXosh(1), NewMCG(1)
+	for i := 0; i < 1000; i++ {
+		r.Bernoulli(0.5)
+		s.Bernoulli(0.5)
+		m.Bernoulli(0.5)
+	}
+	// 1000 fair bits from 16 words.
+	for i := 0; i < 16; i++ {
+		xr.Uint64()
+		xs.Uint64()
+		xm.Uint64()
+	}
+	if r.rng != xr || s.rng != xs || m.rng != xm {
+		t.Errorf("Bernoulli(0.5) did not use one bit per call")
+	}
+	s.Seed(2)
+	m.ReadState(m.State())
+	r.Jump()
+	if s.buf.n != 0 || m.buf.n != 0 || r.buf.n != 0 {
+		t.Errorf("Seed, ReadState or Jump kept the Bernoulli bits")
+	}
+}
+
+func TestMCGJump(t *testing.T) {
+	// 2^14 jumps of 2^48 is 2^62 calls to Uint64, the full period.
+	x := NewMCG(1)
+	y := x
+	for i := 0; i < 1<<14; i++ {
+		y.Jump()
+		if i < (1<<14)-1 && x == y {
+			t.Fatalf("MCG period shorter than 2^62")
+		}
+	}
+	if x != y {
+		t.Errorf("2^14 jumps of MCG is not the full period")
+	}
+}
+
+func TestNextMCG(t *testing.T) {
+	s := NewOutlet(1)
+	x := s.NextMCG()
+	y := s.NextMCG()
+	z := x
+	z.Jump()
+	if y.Uint64() != z.Uint64() {
+		t.Errorf("y.Uint64() != z.Uint64()")
+	}
+	b := x.State()
+	u := x.Uint64()
+	x.ReadState(b)
+	if x.Uint64() != u {
+		t.Errorf("MCG ReadState(State()) changed the state")
+	}
+	x.ReadState(make([]byte, MCGStateSize))
+	if x.state != 1 {
+		t.Errorf("MCG ReadState of an even state: %d", x.state)
+	}
+	for range MCGStreams - 2 {
+		s.NextMCG()
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NextMCG did not panic after MCGStreams streams")
+		}
+	}()
+	s.NextMCGPrng()
+}
+
+func TestXoshBisect(t *testing.T) {
+	x, y := NewXosh(1), NewXosh(1)
+	for i := 0; i < 100000; i++ {
+		if x.Float64Bisect(false) != y.Float64full() {
+			t.Fatalf("Xosh Float64Bisect != Float64full at %d", i)
+		}
+	}
+}
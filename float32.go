@@ -0,0 +1,209 @@
+package prng
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Uniform float32s from [0, 1). The functions use the float64 constructions
+// with the float32 exponent bias 127 and 23 mantissa bits: the count z of the
+// leading zeros of a uniform random word gives the binade [2^-z, 2^-z+1) and
+// the following 23 bits the mantissa. Float32 is 2^24 evenly spaced floats,
+// Float32_32 makes a float32 of 32 random bits and Float32full and
+// Float32fullR read more words as long as needed for all the floats down to
+// the subnormal 2^-149. Casting a Float64 to float32 rounds to nearest and
+// returns 1 for the Float64s above 1 - 2^-25.
+//
+// The methods of Xoro in this file are copied to Xosh and MCG by gen.go.
+
+// float32_32 returns a float32 of the high 32 bits of u. It includes
+// all floats in [2^-9, 1) and 2^23 evenly spaced floats in [0, 2^-9)
+// with spacing 2^-32.
+func float32_32(u uint64) float32 {
+	w := uint32(u >> 32)
+	if w == 0 { return 0 }
+	z := uint32(bits.LeadingZeros32(w)) + 1
+	return math.Float32frombits((127 - z) << 23 | w << z >> 9)
+}
+
+// Float32 returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution is 2^24 evenly spaced floats with spacing 2^-24.
+func (x *Xoro) Float32() float32 {
+	return float32(x.Uint64() >> 40) * 0x1p-24
+}
+
+// Float32_32 returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes all floats in [2^-9, 1) and 2^23 evenly spaced
+// floats in [0, 2^-9) with spacing 2^-32.
+func (x *Xoro) Float32_32() float32 {
+	return float32_32(x.Uint64())
+}
+
+// Float32full returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes all floats in [0, 1).
+// Float32full is equivalent to Float32Bisect in truncate mode.
+func (x *Xoro) Float32full() float32 {
+
+	u := x.Uint64()
+	z := uint64(bits.LeadingZeros64(u)) + 1
+	if z <= 41 {                                 // 1 - 2^-41 of cases
+		return math.Float32frombits(uint32((127 - z) << 23 | u << z >> 41))
+	}
+	z--
+	exp := uint64(0)
+	for u == 0 {
+		u = x.Uint64()
+		z = uint64(bits.LeadingZeros64(u))
+		exp += 64
+		if exp + z >= 149 { return 0 }
+	}
+	u = u << z | x.Uint64() >> (64 - z)
+	exp += z
+	if exp < 126 {
+		return math.Float32frombits(uint32((126 - exp) << 23 | u << 1 >> 41))
+	}
+	return math.Float32frombits(uint32(u >> (exp - 126) >> 41)) // 2^23 subnormal floats
+}
+
+// Float32fullR returns a uniformly distributed pseudo-random float32 from [0, 1]
+// using rounding. The distribution includes all floats in [0, 1].
+// Float32fullR is equivalent to Float32Bisect in rounding mode.
+func (x *Xoro) Float32fullR() float32 {
+
+	u := x.Uint64()
+	z := uint64(bits.LeadingZeros64(u)) + 1
+	if z <= 40 {
+		return math.Float32frombits(uint32((((127 - z) << 24 | u << z >> 40) + 1) >> 1))
+	}
+	z--
+	exp := uint64(0)
+	for u == 0 {
+		u = x.Uint64()
+		z = uint64(bits.LeadingZeros64(u))
+		exp += 64
+		if exp + z > 149 { return 0 }
+	}
+	u = u << z | x.Uint64() >> (64 - z)
+	exp += z
+	if exp < 126 {
+		return math.Float32frombits(uint32((((126 - exp) << 24 | u << 1 >> 40) + 1) >> 1))
+	}
+	return math.Float32frombits(uint32((u >> (exp - 126) >> 40 + 1) >> 1))
+}
+
+// RandomReal32 returns a uniformly distributed pseudo-random float32 from [0, 1].
+// The distribution includes all floats, but may miss very few
+// subnormal floats in [0, 2^-126).
+// RandomReal32 is equivalent to Float32Bisect in rounding mode in [2^-125, 1].
+func (x *Xoro) RandomReal32() float32 {
+
+	u := x.Uint64()
+	z := uint64(bits.LeadingZeros64(u))
+	exp := uint64(64)
+	for u == 0 {
+		u = x.Uint64()
+		z = uint64(bits.LeadingZeros64(u))
+		exp += 64
+		if exp + z > 149 + 64 { return 0 }
+	}
+	u = u << z | x.Uint64() >> (64 - z)
+	return float32(ldexp(float64(float32(u | 1)), exp + z))
+}
+
+// Float32Bisect returns a uniformly distributed pseudo-random float32 from [0, 1).
+// If round is true, rounding is applied and the range is [0, 1].
+// All floats, normal and subnormal, are included. Float32Bisect is a slow
+// function only for validating the float32 functions.
+func (x *Xoro) Float32Bisect(round bool) float32 {
+
+	left, mean, right := float32(0), float32(0.5), float32(1)
+	for {
+		u := x.Uint64()
+		for b := 0; b < 64; b++ {
+
+			if u & (1<<63) != 0 {
+				left = mean
+			} else {
+				right = mean
+			}
+			u <<= 1
+			mean = (left + right) / 2
+			if mean == left || mean == right {
+				if !round {
+					return left
+				}
+				if b == 63 {
+					u = x.Uint64()
+				}
+				if u & (1<<63) != 0 {
+					return right
+				}
+				return left
+			}
+		}
+	}
+}
+
+// Float32_32 returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes all floats in [2^-9, 1) and 2^23 evenly spaced
+// floats in [0, 2^-9) with spacing 2^-32.
+func (r *Prng) Float32_32() float32 {
+	return r.rng.Float32_32()
+}
+
+// Float32full returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes all floats in [0, 1).
+func (r *Prng) Float32full() float32 {
+	return r.rng.Float32full()
+}
+
+// Float32fullR returns a uniformly distributed pseudo-random float32 from [0, 1]
+// using rounding. The distribution includes all floats in [0, 1].
+func (r *Prng) Float32fullR() float32 {
+	return r.rng.Float32fullR()
+}
+
+// RandomReal32 returns a uniformly distributed pseudo-random float32 from [0, 1].
+// The distribution includes all floats in [2^-125, 1] and 0.
+func (r *Prng) RandomReal32() float32 {
+	return r.rng.RandomReal32()
+}
+
+// Float32Bisect returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes all floats. Float32Bisect is a slow function only
+// for validating other functions distributions. If round is true, rounding is used.
+func (r *Prng) Float32Bisect(round bool) float32 {
+	return r.rng.Float32Bisect(round)
+}
+
+// Float32_32 returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes all floats in [2^-9, 1) and 2^23 evenly spaced
+// floats in [0, 2^-9) with spacing 2^-32.
+func Float32_32() float32 {
+	return globalPrng.rng.Float32_32()
+}
+
+// Float32full returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes all floats in [0, 1).
+func Float32full() float32 {
+	return globalPrng.rng.Float32full()
+}
+
+// Float32fullR returns a uniformly distributed pseudo-random float32 from [0, 1]
+// using rounding. The distribution includes all floats in [0, 1].
+func Float32fullR() float32 {
+	return globalPrng.rng.Float32fullR()
+}
+
+// RandomReal32 returns a uniformly distributed pseudo-random float32 from [0, 1].
+// The distribution includes all floats in [2^-125, 1] and 0.
+func RandomReal32() float32 {
+	return globalPrng.rng.RandomReal32()
+}
+
+// Float32Bisect returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes all floats. Float32Bisect is a slow function only
+// for validating other functions distributions. If round is true, rounding is used.
+func Float32Bisect(round bool) float32 {
+	return globalPrng.rng.Float32Bisect(round)
+}
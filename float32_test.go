@@ -0,0 +1,198 @@
+package prng
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/bits"
+	"testing"
+)
+
+// exhaustive runs the float32 bucket tests over all 2^32 bit patterns,
+// which takes minutes. By default every 4099th pattern is tested.
+var exhaustive = flag.Bool("exhaustive", false, "test all 2^32 float32 bit patterns")
+
+// wordSource returns the words w and then fill. It has the float32
+// methods of Xoro, generated by gen.go to wordsource_test.go.
+type wordSource struct {
+	w    [3]uint64
+	i    int
+	fill uint64
+}
+
+func (s *wordSource) Uint64() uint64 {
+	if s.i < len(s.w) {
+		s.i++
+		return s.w[s.i - 1]
+	}
+	return s.fill
+}
+
+// fraction returns the 192 bit binary fraction of v in [0, 1), most
+// significant word first. v must be a multiple of 2^-192.
+func fraction(v float64) (w [3]uint64) {
+	if v == 0 {
+		return
+	}
+	b := math.Float64bits(v)
+	m := b << 11 | 1<<63
+	s := 1022 - b >> 52                          // leading zeros of v
+	q, r := s / 64, s % 64
+	w[q] = m >> r
+	if r > 0 && q < 2 {
+		w[q + 1] = m << (64 - r)
+	}
+	return
+}
+
+// at sets s to the source of the uniform real v.
+func (s *wordSource) at(v float64) *wordSource {
+	s.w, s.i, s.fill = fraction(v), 0, 0
+	return s
+}
+
+// below sets s to the source of the reals just below v.
+func (s *wordSource) below(v float64) *wordSource {
+	w := fraction(v)
+	var b uint64
+	w[2], b = bits.Sub64(w[2], 1, 0)
+	w[1], b = bits.Sub64(w[1], 0, b)
+	w[0], _ = bits.Sub64(w[0], 0, b)
+	s.w, s.i, s.fill = w, 0, ^uint64(0)
+	return s
+}
+
+// TestFloat32Buckets tests for the float32 bit patterns x in [0, 1] that the
+// reals mapped to x are the bucket [x, next(x)) in truncate mode and
+// [(prev(x) + x)/2, (x + next(x))/2) in rounding mode. The float32 functions
+// are monotonic in the uniform real, so the probability of x is the width of
+// its bucket. The other bit patterns are negative, NaN or above 1.
+func TestFloat32Buckets(t *testing.T) {
+	src := &wordSource{}
+	if y := src.at(0).Float32full(); y != 0 {
+		t.Errorf("Float32full(0) = %g", y)
+	}
+	if y := src.below(0x1p-150).Float32fullR(); y != 0 {
+		t.Errorf("Float32fullR(2^-150-) = %g", y)
+	}
+	if y := src.at(0).RandomReal32(); y != 0 {
+		t.Errorf("RandomReal32(0) = %g", y)
+	}
+	const one, parts = 0x3f800000, 16
+	for i := uint32(0); i < parts; i++ {
+		lo, hi := 1 + i * (one / parts), 1 + (i + 1) * (one / parts)
+		t.Run(fmt.Sprintf("%08x", lo), func(t *testing.T) {
+			t.Parallel()
+			testFloat32Buckets(t, lo, hi)
+		})
+	}
+}
+
+// testFloat32Buckets tests the floats of the bit patterns [lo, hi).
+func testFloat32Buckets(t *testing.T, lo, hi uint32) {
+	step := uint32(4099)
+	if *exhaustive {
+		step = 1
+	}
+	src := &wordSource{}
+	fails := 0
+	fail := func(name string, x, y float32) {
+		if fails++; fails <= 10 {
+			t.Errorf("%s: got %g, want %g", name, y, x)
+		}
+	}
+	for b := lo; b < hi; b += step {
+		x := math.Float32frombits(b)
+		prev := math.Nextafter32(x, 0)
+		if x < 1 {
+			if y := src.at(float64(x)).Float32full(); y != x {
+				fail("Float32full", x, y)
+			}
+			if y := src.below(float64(x)).Float32full(); y != prev {
+				fail("Float32full below", prev, y)
+			}
+		}
+		mid := (float64(prev) + float64(x)) / 2
+		if y := src.at(mid).Float32fullR(); y != x {
+			fail("Float32fullR", x, y)
+		}
+		if y := src.below(mid).Float32fullR(); y != prev {
+			fail("Float32fullR below", prev, y)
+		}
+		if x < 0x1p-125 {
+			continue
+		}
+		if y := src.at(mid).RandomReal32(); y != x {
+			fail("RandomReal32", x, y)
+		}
+		if y := src.below(mid).RandomReal32(); y != prev {
+			fail("RandomReal32 below", prev, y)
+		}
+	}
+}
+
+// TestFloat32_32Buckets tests for the 2^32 random words that Float32_32 is the
+// truncation of the word times 2^-32 to a float32.
+func TestFloat32_32Buckets(t *testing.T) {
+	step := uint64(4099)
+	if *exhaustive {
+		step = 1
+	}
+	for u := uint64(0); u < 1<<32; u += step {
+		w := uint32(u)
+		if l := bits.Len32(w); l > 24 {
+			w &^= 1 << (l - 24) - 1
+		}
+		if y, x := float32_32(u << 32), float32(w) * 0x1p-32; y != x {
+			t.Fatalf("float32_32(%#x): got %g, want %g", u, y, x)
+		}
+	}
+}
+
+// TestFloat32Bisect tests the bisection oracle at the bucket edges.
+func TestFloat32Bisect(t *testing.T) {
+	src := &wordSource{}
+	for b := uint64(1); b <= 0x3f800000; b += 65537 {
+		x := math.Float32frombits(uint32(b))
+		prev := math.Nextafter32(x, 0)
+		mid := (float64(prev) + float64(x)) / 2
+		if x < 1 {
+			if y := src.at(float64(x)).Float32Bisect(false); y != x {
+				t.Errorf("Float32Bisect(%g): got %g", x, y)
+			}
+			if y := src.below(float64(x)).Float32Bisect(false); y != prev {
+				t.Errorf("Float32Bisect below %g: got %g, want %g", x, y, prev)
+			}
+		}
+		if y := src.at(mid).Float32Bisect(true); y != x {
+			t.Errorf("Float32Bisect round %g: got %g", x, y)
+		}
+		if y := src.below(mid).Float32Bisect(true); y != prev {
+			t.Errorf("Float32Bisect round below %g: got %g, want %g", x, y, prev)
+		}
+	}
+}
+
+func TestFloat32Generators(t *testing.T) {
+	x, y := NewXoro(1), NewXoro(1)
+	for i := 0; i < 100000; i++ {
+		if x.Float32full() != y.Float32Bisect(false) {
+			t.Fatalf("Xoro Float32full != Float32Bisect at %d", i)
+		}
+	}
+	m, n := NewMCG(1), NewMCG(1)
+	for i := 0; i < 100000; i++ {
+		if m.Float32fullR() != n.Float32Bisect(true) {
+			t.Fatalf("MCG Float32fullR != Float32Bisect at %d", i)
+		}
+	}
+	s := NewXosh(1)
+	for i := 0; i < 100000; i++ {
+		if f := s.Float32(); f >= 1 {
+			t.Fatalf("Xosh Float32 = %g", f)
+		}
+		if f := s.Float32_32(); f >= 1 {
+			t.Fatalf("Xosh Float32_32 = %g", f)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+//go:build ignore
+
+// gen generates xoshprng.go and mcgprng.go from the methods of Prng, and
+// xoshgen.go and mcggen.go from the methods of Xoro in the engineFiles.
+// The tests get the float32 methods of Xoro for a source of given words.
+// A XoshPrng and a MCGPrng are copies of Prng with the generator Xosh
+// or MCG. Run by go generate after changing a method of Prng or Xoro.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// A target is a Prng type generated from Prng.
+type target struct {
+	File    string
+	Name    string // the Prng type
+	Engine  string // the generator type
+	Desc    string // the generator in the type doc
+	Stream  string // the stream length of Jump
+	Streams string // the constant of the number of streams, if limited
+	Imports []string
+}
+
+var targets = []target{
+	{
+		File:   "xoshprng.go",
+		Name:   "XoshPrng",
+		Engine: "Xosh",
+		Desc:   "the xoshiro256+/** generator Xosh",
+		Stream: "2^128",
+	},
+	{
+		File:    "mcgprng.go",
+		Name:    "MCGPrng",
+		Engine:  "MCG",
+		Desc:    "the 64-bit multiplicative congruential generator MCG",
+		Stream:  "2^48",
+		Streams: "MCGStreams",
+	},
+}
+
+var header = template.Must(template.New("header").Parse(`// Code generated by gen.go from the methods of Prng. DO NOT EDIT.
+
+package prng
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+// A {{.Name}} is a Prng with {{.Desc}}.
+// It has the methods of Prng, and a {{.Name}} and a Prng can be used side by side.
+type {{.Name}} struct {
+	rng {{.Engine}}
+	buf bitBuffer
+}
+
+// New{{.Name}} returns a new {{.Name}} seeded with the seed.
+func New{{.Name}}(seed uint64) {{.Name}} {
+	r := {{.Name}}{}
+	r.rng.Seed(seed)
+	return r
+}
+
+// Next{{.Name}} returns the next {{.Name}} from Outlet. Each {{.Name}} has {{.Stream}}
+// long random stream, which is not overlapping with other {{.Name}}s or {{.Engine}}s
+// from the Outlet. {{if .Streams}}It panics after {{.Streams}} streams as Next{{.Engine}}.
+// {{end}}Next{{.Name}} is safe for concurrent use by multiple goroutines.
+func (s *Outlet) Next{{.Name}}() {{.Name}} {
+	return {{.Name}}{rng: s.Next{{.Engine}}()}
+}
+
+// Next{{.Name}} returns the next non-overlapping stream {{.Name}} from globalOutlet.
+func Next{{.Name}}() {{.Name}} {
+	return global.outlet.Next{{.Name}}()
+}
+
+// New{{.Name}}Slice returns a slice of n {{.Name}}s with non-overlapping {{.Stream}}
+// long random streams. The first {{.Name}} is seeded by seed.{{if .Streams}}
+// It panics if n > {{.Streams}}.{{end}}
+func New{{.Name}}Slice(n int, seed uint64) []{{.Name}} {
+{{- if .Streams}}
+	if n > {{.Streams}} {
+		panic("New{{.Name}}Slice: n > {{.Streams}}")
+	}
+{{- end}}
+	s := make([]{{.Name}}, n)
+	s[0].Seed(seed)
+	for i := 1; i < n; i++ {
+		s[i] = s[i-1]
+		s[i].Jump()
+	}
+	return s
+}
+`))
+
+// engineFiles are the files whose methods of Xoro are copied to Xosh and MCG.
+// The methods use only the methods common to the generators.
+var engineFiles = []string{"range.go", "float32.go", "sphere.go", "haar.go", "sorted.go", "sample.go"}
+
+// A copyTarget is a type with the methods of Xoro in the files.
+type copyTarget struct {
+	Type  string
+	File  string
+	Files []string
+}
+
+var copies = []copyTarget{
+	{"Xosh", "xoshgen.go", engineFiles},
+	{"MCG", "mcggen.go", engineFiles},
+	{"wordSource", "wordsource_test.go", []string{"float32.go"}},
+}
+
+var engineHeader = template.Must(template.New("engine").Parse(`// Code generated by gen.go from the methods of Xoro. DO NOT EDIT.
+
+package prng
+{{if .}}
+import (
+{{- range .}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}`))
+
+// docs replaces the doc comments of the methods which depend on the generator.
+var docs = map[string]*template.Template{
+	"Seed": template.Must(template.New("Seed").Parse(`// Seed seeds a {{.Name}} by the seed. Any seed is ok.
+// Do not seed {{.Name}}s created by Next{{.Name}} or New{{.Name}}Slice.
+`)),
+	"Jump": template.Must(template.New("Jump").Parse(`// Jump sets r to the same state as {{.Stream}} calls to r.Uint64.
+{{- if .Streams}}
+// {{.Streams}} jumps go around the period of the generator.
+{{- end}}
+`)),
+}
+
+// A method is the source of a method.
+type method struct {
+	name string
+	doc  string
+	code string // the declaration without the doc comment
+}
+
+func main() {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+	files = slices.DeleteFunc(files, func(name string) bool {
+		return strings.HasSuffix(name, "_test.go") || name == "gen.go" || generated(name)
+	})
+	// prng.go first for the order of the methods in the generated files.
+	slices.SortStableFunc(files, func(a, b string) int {
+		return bool2int(b == "prng.go") - bool2int(a == "prng.go")
+	})
+	methods, imports := collect(files, "Prng")
+	for _, t := range targets {
+		t.Imports = imports
+		var b bytes.Buffer
+		if err := header.Execute(&b, t); err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range methods {
+			b.WriteString("\n")
+			if d, ok := docs[m.name]; ok {
+				if err := d.Execute(&b, t); err != nil {
+					log.Fatal(err)
+				}
+			} else {
+				b.WriteString(m.doc)
+			}
+			code := strings.Replace(m.code, "(r *Prng)", "(r *"+t.Name+")", 1)
+			fmt.Fprintf(&b, "%s\n", code)
+		}
+		write(t.File, b.Bytes())
+	}
+	for _, c := range copies {
+		methods, imports := collect(c.Files, "Xoro")
+		var b bytes.Buffer
+		if err := engineHeader.Execute(&b, imports); err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range methods {
+			code := strings.Replace(m.code, "(x *Xoro)", "(x *"+c.Type+")", 1)
+			fmt.Fprintf(&b, "\n%s%s\n", m.doc, code)
+		}
+		write(c.File, b.Bytes())
+	}
+}
+
+// collect returns the methods of the type recv in the files and the
+// imports used by them.
+func collect(files []string, recv string) (methods []method, imports []string) {
+	fset := token.NewFileSet()
+	for _, name := range files {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		src = bytes.ReplaceAll(src, []byte("\r\n"), []byte("\n"))
+		f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			log.Fatal(err)
+		}
+		paths := importPaths(f)
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || !isRecv(fd.Recv, recv) {
+				continue
+			}
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if id, ok := sel.X.(*ast.Ident); ok && paths[id.Name] != "" {
+						imports = append(imports, paths[id.Name])
+					}
+				}
+				return true
+			})
+			m := method{name: fd.Name.Name}
+			if fd.Doc != nil {
+				m.doc = string(src[fset.Position(fd.Doc.Pos()).Offset:fset.Position(fd.Pos()).Offset])
+			}
+			m.code = string(src[fset.Position(fd.Pos()).Offset:fset.Position(fd.End()).Offset])
+			methods = append(methods, m)
+		}
+	}
+	slices.Sort(imports)
+	return methods, slices.Compact(imports)
+}
+
+func write(name string, b []byte) {
+	if err := os.WriteFile(name, b, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// isRecv reports whether the receiver is *name.
+func isRecv(recv *ast.FieldList, name string) bool {
+	if recv == nil || len(recv.List) != 1 {
+		return false
+	}
+	star, ok := recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	id, ok := star.X.(*ast.Ident)
+	return ok && id.Name == name
+}
+
+// importPaths returns the import paths of f by the package names.
+func importPaths(f *ast.File) map[string]string {
+	paths := map[string]string{}
+	for _, im := range f.Imports {
+		path, err := strconv.Unquote(im.Path.Value)
+		if err != nil {
+			log.Fatal(err)
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if im.Name != nil {
+			name = im.Name.Name
+		}
+		paths[name] = path
+	}
+	return paths
+}
+
+// generated reports whether the file is generated by gen.go.
+func generated(name string) bool {
+	for _, t := range targets {
+		if t.File == name {
+			return true
+		}
+	}
+	for _, c := range copies {
+		if c.File == name {
+			return true
+		}
+	}
+	return false
+}
+
+func bool2int(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
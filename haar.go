@@ -0,0 +1,243 @@
+package prng
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Haar distributed random matrices, the uniform distributions on the groups
+// O(n), SO(n) and U(n). A matrix of independent normal variates, complex
+// for U(n), is factored A = QR by Householder reflections and Q is multiplied
+// by the phases of the diagonal of R. Without the phases Q depends on the
+// sign conventions of the factorization and is not Haar distributed.
+// Mezzadri: How to generate random matrices from the classical compact groups,
+// https://arxiv.org/abs/math-ph/0609050. SO(n) is O(n) with the first column
+// negated if the determinant is -1. SO(3) is made of a uniform unit quaternion
+// by Marsaglia's 4-D method and SO(2) of a uniform point on the circle.
+// The matrices are n x n and row-major in dst. The factorization allocates
+// n*n + n scratch floats, complex for U(n), on each call, except for SO(n),
+// n <= 3.
+//
+// The methods of Xoro in this file are copied to Xosh and MCG by gen.go.
+
+// Orthogonal sets dst to a Haar distributed pseudo-random n x n orthogonal
+// matrix in row-major order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n + n scratch floats.
+func (x *Xoro) Orthogonal(n int, dst []float64) {
+	x.orthogonal(n, dst, "Orthogonal")
+}
+
+// SpecialOrthogonal sets dst to a Haar distributed pseudo-random n x n
+// rotation matrix, an orthogonal matrix with determinant 1, in row-major
+// order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n + n scratch floats for n > 3.
+func (x *Xoro) SpecialOrthogonal(n int, dst []float64) {
+	if n < 1 || len(dst) < n * n {
+		panic("invalid argument to SpecialOrthogonal")
+	}
+	switch n {
+	case 1:
+		dst[0] = 1
+	case 2:
+		var c [2]float64
+		x.OnSphere(c[:])
+		dst[0], dst[1] = c[0], -c[1]
+		dst[2], dst[3] = c[1], c[0]
+	case 3:
+		w, b, c, d := x.quaternion()
+		dst[0] = 1 - 2 * (c * c + d * d)
+		dst[1] = 2 * (b * c - w * d)
+		dst[2] = 2 * (b * d + w * c)
+		dst[3] = 2 * (b * c + w * d)
+		dst[4] = 1 - 2 * (b * b + d * d)
+		dst[5] = 2 * (c * d - w * b)
+		dst[6] = 2 * (b * d - w * c)
+		dst[7] = 2 * (c * d + w * b)
+		dst[8] = 1 - 2 * (b * b + c * c)
+	default:
+		if x.orthogonal(n, dst, "SpecialOrthogonal") < 0 {
+			for i := 0; i < n; i++ {
+				dst[i*n] = -dst[i*n]
+			}
+		}
+	}
+}
+
+// Unitary sets dst to a Haar distributed pseudo-random n x n unitary
+// matrix in row-major order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n complex and n float scratch values.
+func (x *Xoro) Unitary(n int, dst []complex128) {
+	if n < 1 || len(dst) < n * n {
+		panic("invalid argument to Unitary")
+	}
+	a := make([]complex128, n * n)
+	for i := range a {
+		a[i] = complex(x.NormFloat64(), x.NormFloat64()) * math.Sqrt2 / 2
+	}
+	beta := make([]float64, n)
+	for k := 0; k < n; k++ {
+		s := 0.0
+		for i := k; i < n; i++ {
+			s += real(a[i*n + k]) * real(a[i*n + k]) + imag(a[i*n + k]) * imag(a[i*n + k])
+		}
+		akk := a[k*n + k]
+		phase := complex(1, 0)
+		if akk != 0 {
+			phase = akk / complex(cmplx.Abs(akk), 0)
+		}
+		alpha := -phase * complex(math.Sqrt(s), 0)
+		v := akk - alpha
+		a[k*n + k] = v
+		if vv := s - real(akk * cmplx.Conj(akk)) + real(v * cmplx.Conj(v)); vv > 0 {
+			beta[k] = 2 / vv
+		}
+		for j := k + 1; j < n; j++ {
+			var dot complex128
+			for i := k; i < n; i++ {
+				dot += cmplx.Conj(a[i*n + k]) * a[i*n + j]
+			}
+			f := complex(beta[k], 0) * dot
+			for i := k; i < n; i++ {
+				a[i*n + j] -= f * a[i*n + k]
+			}
+		}
+		// R_kk = alpha, Q is multiplied by its phase -phase.
+		dst[k*n + k] = -phase
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j {
+				dst[i*n + j] = 0
+			}
+		}
+	}
+	for k := n - 1; k >= 0; k-- {
+		for j := k; j < n; j++ {
+			var dot complex128
+			for i := k; i < n; i++ {
+				dot += cmplx.Conj(a[i*n + k]) * dst[i*n + j]
+			}
+			f := complex(beta[k], 0) * dot
+			for i := k; i < n; i++ {
+				dst[i*n + j] -= f * a[i*n + k]
+			}
+		}
+	}
+}
+
+// orthogonal sets dst to a Haar distributed n x n orthogonal matrix and
+// returns its determinant.
+func (x *Xoro) orthogonal(n int, dst []float64, name string) float64 {
+	if n < 1 || len(dst) < n * n {
+		panic("invalid argument to " + name)
+	}
+	a := make([]float64, n * n)
+	for i := range a {
+		a[i] = x.NormFloat64()
+	}
+	beta := make([]float64, n)
+	det := 1.0
+	for k := 0; k < n; k++ {
+		// Householder vector v of column k below the diagonal, H_k a_k = alpha e_k.
+		s := 0.0
+		for i := k; i < n; i++ {
+			s += a[i*n + k] * a[i*n + k]
+		}
+		akk := a[k*n + k]
+		alpha := -math.Copysign(math.Sqrt(s), akk)
+		v := akk - alpha
+		a[k*n + k] = v
+		if vv := s - akk * akk + v * v; vv > 0 {
+			beta[k] = 2 / vv
+			det = -det                               // a reflection
+		}
+		for j := k + 1; j < n; j++ {
+			dot := 0.0
+			for i := k; i < n; i++ {
+				dot += a[i*n + k] * a[i*n + j]
+			}
+			f := beta[k] * dot
+			for i := k; i < n; i++ {
+				a[i*n + j] -= f * a[i*n + k]
+			}
+		}
+		// R_kk = alpha, Q is multiplied by its sign.
+		dst[k*n + k] = math.Copysign(1, alpha)
+		det *= dst[k*n + k]
+	}
+	// Q diag(sign R) = H_0 H_1 ... H_n-1 diag(sign R), the reflections from
+	// the last to the first applied to the diagonal matrix in dst.
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j {
+				dst[i*n + j] = 0
+			}
+		}
+	}
+	for k := n - 1; k >= 0; k-- {
+		for j := k; j < n; j++ {
+			dot := 0.0
+			for i := k; i < n; i++ {
+				dot += a[i*n + k] * dst[i*n + j]
+			}
+			f := beta[k] * dot
+			for i := k; i < n; i++ {
+				dst[i*n + j] -= f * a[i*n + k]
+			}
+		}
+	}
+	return det
+}
+
+// quaternion returns a uniform unit quaternion w + bi + cj + dk by
+// Marsaglia's method of two points in the unit disk.
+func (x *Xoro) quaternion() (w, b, c, d float64) {
+	w, b, s1 := x.disk()
+	c, d, s2 := x.disk()
+	r := math.Sqrt((1 - s1) / s2)
+	return w, b, c * r, d * r
+}
+
+// Orthogonal sets dst to a Haar distributed pseudo-random n x n orthogonal
+// matrix in row-major order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n + n scratch floats.
+func (r *Prng) Orthogonal(n int, dst []float64) {
+	r.rng.Orthogonal(n, dst)
+}
+
+// SpecialOrthogonal sets dst to a Haar distributed pseudo-random n x n
+// rotation matrix, an orthogonal matrix with determinant 1, in row-major
+// order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n + n scratch floats for n > 3.
+func (r *Prng) SpecialOrthogonal(n int, dst []float64) {
+	r.rng.SpecialOrthogonal(n, dst)
+}
+
+// Unitary sets dst to a Haar distributed pseudo-random n x n unitary
+// matrix in row-major order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n complex and n float scratch values.
+func (r *Prng) Unitary(n int, dst []complex128) {
+	r.rng.Unitary(n, dst)
+}
+
+// Orthogonal sets dst to a Haar distributed pseudo-random n x n orthogonal
+// matrix in row-major order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n + n scratch floats.
+func Orthogonal(n int, dst []float64) {
+	globalPrng.Orthogonal(n, dst)
+}
+
+// SpecialOrthogonal sets dst to a Haar distributed pseudo-random n x n
+// rotation matrix, an orthogonal matrix with determinant 1, in row-major
+// order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n + n scratch floats for n > 3.
+func SpecialOrthogonal(n int, dst []float64) {
+	globalPrng.SpecialOrthogonal(n, dst)
+}
+
+// Unitary sets dst to a Haar distributed pseudo-random n x n unitary
+// matrix in row-major order. It panics if n < 1 or len(dst) < n*n.
+// It allocates n*n complex and n float scratch values.
+func Unitary(n int, dst []complex128) {
+	globalPrng.Unitary(n, dst)
+}
@@ -0,0 +1,220 @@
+package prng
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// det returns the determinant of the n x n row-major matrix a by Gaussian
+// elimination with partial pivoting.
+func det(n int, a []float64) float64 {
+	m := append([]float64(nil), a[:n*n]...)
+	d := 1.0
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if abs(m[i*n + k]) > abs(m[p*n + k]) {
+				p = i
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				m[k*n + j], m[p*n + j] = m[p*n + j], m[k*n + j]
+			}
+			d = -d
+		}
+		d *= m[k*n + k]
+		for i := k + 1; i < n; i++ {
+			f := m[i*n + k] / m[k*n + k]
+			for j := k; j < n; j++ {
+				m[i*n + j] -= f * m[k*n + j]
+			}
+		}
+	}
+	return d
+}
+
+// meanTest reports an error if the mean of a differs from want by more
+// than 5 standard errors.
+func meanTest(t *testing.T, name string, a []float64, want float64) {
+	n := float64(len(a))
+	s, s2 := 0.0, 0.0
+	for _, v := range a {
+		s += v
+		s2 += v * v
+	}
+	mean := s / n
+	se := math.Sqrt((s2 / n - mean * mean) / n)
+	if abs(mean - want) > 5 * se + 1e-12 {
+		t.Errorf("%s mean %f, want %g, standard error %f", name, mean, want, se)
+	}
+}
+
+func TestOrthogonal(t *testing.T) {
+	r := New(1)
+	for n := 1; n <= 7; n++ {
+		q := make([]float64, n * n)
+		for _, so := range []bool{false, true} {
+			for c := 0; c < 200; c++ {
+				if so {
+					r.SpecialOrthogonal(n, q)
+				} else {
+					r.Orthogonal(n, q)
+				}
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						s := 0.0
+						for k := 0; k < n; k++ {
+							s += q[k*n + i] * q[k*n + j]
+						}
+						if i == j {
+							s--
+						}
+						if abs(s) > 1e-13 {
+							t.Fatalf("n %d: Q^T Q - I [%d,%d] = %g", n, i, j, s)
+						}
+					}
+				}
+				if d := det(n, q); so && abs(d - 1) > 1e-12 {
+					t.Fatalf("SpecialOrthogonal n %d det = %g", n, d)
+				}
+			}
+		}
+	}
+}
+
+// TestOrthogonalHaar tests that the entries and the projections a^T Q b of
+// fixed unit vectors a and b are distributed as a coordinate of a uniform
+// point on the sphere, and the moments of the trace.
+func TestOrthogonalHaar(t *testing.T) {
+	const rounds = 50000
+	x := NewXosh(1)
+	for n := 2; n <= 5; n++ {
+		a, b := make([]float64, n), make([]float64, n)
+		x.OnSphere(a)
+		x.OnSphere(b)
+		q := make([]float64, n * n)
+		for _, so := range []bool{false, true} {
+			first, last, proj := make([]float64, rounds), make([]float64, rounds), make([]float64, rounds)
+			tr, tr2 := make([]float64, rounds), make([]float64, rounds)
+			for c := 0; c < rounds; c++ {
+				if so {
+					x.SpecialOrthogonal(n, q)
+				} else {
+					x.Orthogonal(n, q)
+				}
+				first[c], last[c] = q[0], q[n*n - 1]
+				s := 0.0
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						proj[c] += a[i] * q[i*n + j] * b[j]
+					}
+					s += q[i*n + i]
+				}
+				tr[c], tr2[c] = s, s * s
+			}
+			for _, e := range [][]float64{first, last, proj} {
+				d := ksOneSample(e, func(t float64) float64 { return sphereMarginal(n, t) })
+				if d > 1.95 / math.Sqrt(rounds) {
+					t.Errorf("n %d SO %v entry KS D = %f", n, so, d)
+				}
+			}
+			// E tr Q = 0 and E (tr Q)^2 = 1 for O(n), n >= 2, and SO(n), n >= 3.
+			meanTest(t, "tr Q", tr, 0)
+			if !so || n >= 3 {
+				meanTest(t, "(tr Q)^2", tr2, 1)
+			}
+		}
+	}
+}
+
+func TestSpecialOrthogonal3(t *testing.T) {
+	// The rotation angle θ of a Haar rotation has the cdf (θ - sin θ) / π.
+	const rounds = 100000
+	x := NewXoro(1)
+	q := make([]float64, 9)
+	a := make([]float64, rounds)
+	for i := range a {
+		x.SpecialOrthogonal(3, q)
+		c := (q[0] + q[4] + q[8] - 1) / 2
+		a[i] = math.Acos(math.Max(-1, math.Min(1, c)))
+	}
+	d := ksOneSample(a, func(t float64) float64 { return (t - math.Sin(t)) / math.Pi })
+	if d > 1.95 / math.Sqrt(rounds) {
+		t.Errorf("SO(3) rotation angle KS D = %f", d)
+	}
+}
+
+func TestUnitary(t *testing.T) {
+	const rounds = 50000
+	x := NewMCG(1)
+	for n := 1; n <= 4; n++ {
+		q := make([]complex128, n * n)
+		a, b := make([]float64, n), make([]float64, n)
+		x.OnSphere(a)
+		x.OnSphere(b)
+		first, proj := make([]float64, rounds), make([]float64, rounds)
+		tr2, tr4 := make([]float64, rounds), make([]float64, rounds)
+		for c := 0; c < rounds; c++ {
+			x.Unitary(n, q)
+			if c < 200 {
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						var s complex128
+						for k := 0; k < n; k++ {
+							s += cmplx.Conj(q[k*n + i]) * q[k*n + j]
+						}
+						if i == j {
+							s--
+						}
+						if cmplx.Abs(s) > 1e-13 {
+							t.Fatalf("n %d: Q^H Q - I [%d,%d] = %g", n, i, j, s)
+						}
+					}
+				}
+			}
+			var p, s complex128
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					p += complex(a[i] * b[j], 0) * q[i*n + j]
+				}
+				s += q[i*n + i]
+			}
+			first[c] = real(q[0]) * real(q[0]) + imag(q[0]) * imag(q[0])
+			proj[c] = real(p) * real(p) + imag(p) * imag(p)
+			m := real(s) * real(s) + imag(s) * imag(s)
+			tr2[c], tr4[c] = m, m * m
+		}
+		if n == 1 {
+			continue
+		}
+		// |Q_ij|^2 and |a^H Q b|^2 are Beta(1, n - 1) distributed.
+		for _, e := range [][]float64{first, proj} {
+			d := ksOneSample(e, func(t float64) float64 { return 1 - math.Pow(1 - t, float64(n - 1)) })
+			if d > 1.95 / math.Sqrt(rounds) {
+				t.Errorf("U(%d) entry KS D = %f", n, d)
+			}
+		}
+		// E |tr Q|^2k = k! for k <= n.
+		meanTest(t, "|tr Q|^2", tr2, 1)
+		meanTest(t, "|tr Q|^4", tr4, 2)
+	}
+}
+
+func TestHaarPanics(t *testing.T) {
+	for _, f := range []func(){
+		func() { Orthogonal(0, nil) },
+		func() { SpecialOrthogonal(3, make([]float64, 8)) },
+		func() { Unitary(2, make([]complex128, 3)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
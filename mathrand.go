@@ -0,0 +1,150 @@
+package prng
+
+// The rest of math/rand methods of Prng and the top level functions.
+
+// Int31 returns a non-negative pseudo-random int32.
+func (r *Prng) Int31() int32 {
+	return int32(r.rng.Uint64() >> 33)
+}
+
+// Uint32 returns a pseudo-random uint32.
+func (r *Prng) Uint32() uint32 {
+	return uint32(r.rng.Uint64() >> 32)
+}
+
+// Int31n returns an unbiased pseudo-random number in [0,n) as an int32.
+// It panics if n <= 0.
+func (r *Prng) Int31n(n int32) int32 {
+	if n <= 0 {
+		panic("invalid argument to Int31n")
+	}
+	return int32(r.rng.uint64n(uint64(n)))
+}
+
+// Float32 returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes 2^24 evenly spaced floats with spacing 2^-24.
+func (r *Prng) Float32() float32 {
+	return r.rng.Float32()
+}
+
+// Perm returns a pseudo-random permutation of the integers [0,n) as a slice.
+func (r *Prng) Perm(n int) []int {
+	m := make([]int, n)
+	for i := 0; i < n; i++ {
+		j := int(r.rng.uint64n(uint64(i + 1)))
+		m[i] = m[j]
+		m[j] = i
+	}
+	return m
+}
+
+// Shuffle pseudo-randomizes the order of n elements by Fisher-Yates shuffle.
+// swap swaps the elements with indexes i and j. It panics if n < 0.
+func (r *Prng) Shuffle(n int, swap func(i, j int)) {
+	if n < 0 {
+		panic("invalid argument to Shuffle")
+	}
+	for i := n - 1; i > 0; i-- {
+		j := int(r.rng.uint64n(uint64(i + 1)))
+		swap(i, j)
+	}
+}
+
+// Read fills p with pseudo-random bytes. It always returns len(p) and a nil error.
+// The bytes of each Uint64 are taken from the lowest byte up.
+func (r *Prng) Read(p []byte) (n int, err error) {
+	var u uint64
+	for i := range p {
+		if i & 7 == 0 {
+			u = r.rng.Uint64()
+		}
+		p[i] = byte(u)
+		u >>= 8
+	}
+	return len(p), nil
+}
+
+// NormFloat64 returns a standard normal distributed pseudo-random float64
+// by 256 layer ziggurat. The tail beyond 3.654 uses Float64full uniforms.
+func (r *Prng) NormFloat64() float64 {
+	return r.rng.NormFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed pseudo-random float64
+// with rate 1 by 256 layer ziggurat.
+func (r *Prng) ExpFloat64() float64 {
+	return r.rng.ExpFloat64()
+}
+
+// ExpFloat64Rate returns an exponentially distributed pseudo-random float64
+// with rate lambda by ExpFloat64. It panics if lambda <= 0.
+func (r *Prng) ExpFloat64Rate(lambda float64) float64 {
+	return r.rng.ExpFloat64Rate(lambda)
+}
+
+// ExpFloat64full returns an exponentially distributed pseudo-random float64
+// with rate 1. ExpFloat64full is exact also in the far tail and near zero.
+func (r *Prng) ExpFloat64full() float64 {
+	return r.rng.ExpFloat64full()
+}
+
+// Int31 returns a non-negative pseudo-random int32.
+func Int31() int32 {
+	return globalPrng.Int31()
+}
+
+// Uint32 returns a pseudo-random uint32.
+func Uint32() uint32 {
+	return globalPrng.Uint32()
+}
+
+// Int31n returns an unbiased pseudo-random number in [0,n) as an int32.
+// It panics if n <= 0.
+func Int31n(n int32) int32 {
+	return globalPrng.Int31n(n)
+}
+
+// Float32 returns a uniformly distributed pseudo-random float32 from [0, 1).
+// The distribution includes 2^24 evenly spaced floats with spacing 2^-24.
+func Float32() float32 {
+	return globalPrng.Float32()
+}
+
+// Perm returns a pseudo-random permutation of the integers [0,n) as a slice.
+func Perm(n int) []int {
+	return globalPrng.Perm(n)
+}
+
+// Shuffle pseudo-randomizes the order of n elements by Fisher-Yates shuffle.
+// swap swaps the elements with indexes i and j. It panics if n < 0.
+func Shuffle(n int, swap func(i, j int)) {
+	globalPrng.Shuffle(n, swap)
+}
+
+// Read fills p with pseudo-random bytes. It always returns len(p) and a nil error.
+func Read(p []byte) (n int, err error) {
+	return globalPrng.Read(p)
+}
+
+// NormFloat64 returns a standard normal distributed pseudo-random float64.
+func NormFloat64() float64 {
+	return globalPrng.NormFloat64()
+}
+
+// ExpFloat64 returns an exponentially distributed pseudo-random float64
+// with rate 1 by 256 layer ziggurat.
+func ExpFloat64() float64 {
+	return globalPrng.ExpFloat64()
+}
+
+// ExpFloat64Rate returns an exponentially distributed pseudo-random float64
+// with rate lambda by ExpFloat64. It panics if lambda <= 0.
+func ExpFloat64Rate(lambda float64) float64 {
+	return globalPrng.ExpFloat64Rate(lambda)
+}
+
+// ExpFloat64full returns an exponentially distributed pseudo-random float64
+// with rate 1. ExpFloat64full is exact also in the far tail and near zero.
+func ExpFloat64full() float64 {
+	return globalPrng.ExpFloat64full()
+}
@@ -0,0 +1,124 @@
+package prng
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// ksTwoSample returns the two-sample Kolmogorov-Smirnov statistic D of a and b.
+// a and b are sorted in place.
+func ksTwoSample(a, b []float64) float64 {
+	sort.Float64s(a)
+	sort.Float64s(b)
+	d := 0.0
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] <= b[j] {
+			i++
+		} else {
+			j++
+		}
+		diff := abs(float64(i)/float64(len(a)) - float64(j)/float64(len(b)))
+		if diff > d {
+			d = diff
+		}
+	}
+	return d
+}
+
+// ksLimit returns the two-sample KS critical value for alpha 0.001.
+func ksLimit(n, m int) float64 {
+	return 1.95 * math.Sqrt(float64(n+m)/float64(n*m))
+}
+
+func TestMathRandDistributions(t *testing.T) {
+	const rounds = 200000
+	r := New(1)
+	m := rand.New(rand.NewSource(1))
+	tests := []struct {
+		name string
+		f, g func() float64
+	}{
+		{"NormFloat64", r.NormFloat64, m.NormFloat64},
+		{"ExpFloat64", r.ExpFloat64, m.ExpFloat64},
+		{"Float32", func() float64 { return float64(r.Float32()) },
+			func() float64 { return float64(m.Float32()) }},
+		{"Int31", func() float64 { return float64(r.Int31()) },
+			func() float64 { return float64(m.Int31()) }},
+		{"Uint32", func() float64 { return float64(r.Uint32()) },
+			func() float64 { return float64(m.Uint32()) }},
+		{"Int31n", func() float64 { return float64(r.Int31n(1e9 + 7)) },
+			func() float64 { return float64(m.Int31n(1e9 + 7)) }},
+	}
+	for _, tc := range tests {
+		a := make([]float64, rounds)
+		b := make([]float64, rounds)
+		for i := range a {
+			a[i] = tc.f()
+			b[i] = tc.g()
+		}
+		d := ksTwoSample(a, b)
+		t.Logf("%-12s KS D = %f", tc.name, d)
+		if d > ksLimit(rounds, rounds) {
+			t.Errorf("%s: distribution differs from math/rand, D = %f", tc.name, d)
+		}
+	}
+}
+
+func TestPermShuffle(t *testing.T) {
+	const n = 8
+	const rounds = 400000
+	const failLim = 3e-2
+	r := New(1)
+	m := rand.New(rand.NewSource(1))
+	var tab, tabm [n][n]int
+	s := make([]int, n)
+	for i := 0; i < rounds; i++ {
+		p := r.Perm(n)
+		pm := m.Perm(n)
+		for j := 0; j < n; j++ {
+			tab[j][p[j]]++
+			tabm[j][pm[j]]++
+		}
+		for j := range s {
+			s[j] = j
+		}
+		r.Shuffle(n, func(i, j int) { s[i], s[j] = s[j], s[i] })
+		for j := 0; j < n; j++ {
+			tab[j][s[j]]++
+		}
+	}
+	expected := rounds / n
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			reldiff := float64(tab[i][j]-2*expected) / float64(2*expected)
+			reldiffm := float64(tabm[i][j]-expected) / float64(expected)
+			if abs(reldiff) > failLim || abs(reldiffm) > failLim {
+				t.Errorf("position %d value %d: %4.2e, math/rand %4.2e", i, j, reldiff, reldiffm)
+			}
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	const rounds = 1 << 22
+	const failLim = 3e-2
+	r := New(1)
+	p := make([]byte, rounds+3)
+	n, err := r.Read(p)
+	if n != len(p) || err != nil {
+		t.Fatalf("Read returned %d, %v", n, err)
+	}
+	var tab [256]int
+	for _, b := range p[:rounds] {
+		tab[b]++
+	}
+	expected := rounds / 256
+	for i, c := range tab {
+		if reldiff := float64(c-expected) / float64(expected); abs(reldiff) > failLim {
+			t.Errorf("byte %d: %d %4.2e", i, c, reldiff)
+		}
+	}
+}
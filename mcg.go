@@ -4,6 +4,7 @@ package prng
 import (
 	"math"
 	"math/bits"
+	"unsafe"
 )
 
 // A MCG implements 64-bit multiplicative congruential pseudorandom number 
@@ -28,6 +29,81 @@ func NewMCG(seed uint64) MCG {
 	return x
 }
 
+// MCGStreams is the number of non-overlapping 2^48 long random streams
+// of a MCG. The 2^14 jumps of 2^48 go around the period 2^62.
+const MCGStreams = 1 << 14
+
+// NextMCG returns the next MCG generator from Outlet. Each generator has
+// 2^48 long random stream, which is not overlapping with other generators streams.
+// An Outlet has MCGStreams streams and NextMCG panics after them, because
+// the next stream would be the first one again.
+// NextMCG is safe for concurrent use by multiple goroutines.
+func (s *Outlet) NextMCG() MCG {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.mcgs == MCGStreams {
+		panic("NextMCG: all MCGStreams streams of the Outlet are used")
+	}
+	s.mcgs++
+	s.mcg.Jump()
+	return s.mcg
+}
+
+// NewMCGSlice returns a slice of n MCG generators with non-overlapping 2^48
+// long random streams. First generator is seeded by seed.
+// It panics if n > MCGStreams.
+func NewMCGSlice(n int, seed uint64) []MCG {
+	if n > MCGStreams {
+		panic("NewMCGSlice: n > MCGStreams")
+	}
+	s := make([]MCG, n)
+	s[0].Seed(seed)
+	for i := 1; i < n; i++ {
+		s[i] = s[i-1]
+		s[i].Jump()
+	}
+	return s
+}
+
+// Jump sets x to the same state as 2^48 calls to x.Uint64.
+// The state after n calls is state * a^n, so the jump multiplier
+// a^(2^48) is got by squaring the multiplier a 48 times.
+// With the period 2^62 Jump gives 2^14 non-overlapping subsequences.
+func (x *MCG) Jump() {
+	a := uint64(0x83b5b142866da9d5)
+	for i := 0; i < 48; i++ {
+		a *= a
+	}
+	x.state *= a
+}
+
+// WriteState writes the current state of the generator x to b.
+func (x *MCG) WriteState(b []byte)  {
+	if len(b) < MCGStateSize {
+		panic("WriteState: byte slice too short")
+	}
+	*(*uint64)(unsafe.Pointer(&b[0])) = bits.ReverseBytes64(x.state)
+}
+
+// State returns the current state of the generator x as []byte.
+func (x *MCG) State() []byte {
+	var b[MCGStateSize]byte
+
+	*(*uint64)(unsafe.Pointer(&b[0])) = bits.ReverseBytes64(x.state)
+	return b[:]
+}
+
+// ReadState reads the state of the generator x from b []byte.
+// The state is made odd as in Seed, because an even state has a shorter
+// period and may reach 0.
+func (x *MCG) ReadState(b []byte) {
+	if len(b) < MCGStateSize {
+		panic("ReadState: byte slice too short")
+	}
+	x.state = bits.ReverseBytes64(*(*uint64)(unsafe.Pointer(&b[0]))) | 1
+}
+
 // Uint64 returns a  pseudo-random uint64 by MCG mod 2^64.
 // The multiplier is picked from Table 6 in Steele & Vigna. Without the
 // xor-rotate scrambler, the last bits are not uniformly distributed.
@@ -39,6 +115,30 @@ func (x *MCG) Uint64() (next uint64) {
 	x.state *= 0x83b5b142866da9d5
 	return 
 }
+// Uint64n returns an unbiased pseudo-random number in [0,n) as an uint64.
+// It panics if n == 0.
+func (x *MCG) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64n")
+	}
+	return x.uint64n(n)
+}
+
+// uint64n is Uint64n without the argument check.
+func (x *MCG) uint64n(n uint64) uint64 {
+	if n & (n - 1) == 0 {
+		return x.Uint64() & (n - 1)
+	}
+	hi, lo := bits.Mul64(x.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(x.Uint64(), n)
+		}
+	}
+	return hi
+}
+
 // Alternative scrambler
 // next = x.state ^ (x.state >> 17)
 
@@ -99,3 +199,96 @@ func (x *MCG) Float64_64() float64 {
 	z := uint64(bits.LeadingZeros64(u)) + 1
 	return math.Float64frombits((1023 - z) << 52 | u << z >> 12)
 }
+
+// Float64_117 returns a uniformly distributed pseudo-random float64 from [0, 1). 
+// The distribution includes all floats in [2^-65, 1) and 2^52 evenly spaced 
+// floats in [0, 2^-65) with spacing 2^-117.
+func (x *MCG) Float64_117() float64 {
+
+	u := x.Uint64()
+	z := uint64(bits.LeadingZeros64(u)) + 1
+	if z <= 12 {  
+		return math.Float64frombits((1023 - z) << 52 | u << z >> 12)
+	}
+	z--
+	u = u << z | x.Uint64() >> (64 - z)
+	return float64(u >> 11) * twoToMinus(53 + z)
+}
+
+// Float64full returns a uniformly distributed pseudo-random float64 from [0, 1). 
+// The distribution includes all floats in [0, 1). 
+// Float64full is equivalent to Float64Bisect in truncate mode.
+func (x *MCG) Float64full() float64 {
+
+	u := x.Uint64()
+	z := uint64(bits.LeadingZeros64(u)) + 1
+	if z <= 12 {
+		return math.Float64frombits((1023 - z) << 52 | u << z >> 12)
+	}
+	z--
+	exp := uint64(0)
+	for u == 0 { 
+		u = x.Uint64() 
+		z = uint64(bits.LeadingZeros64(u))
+		exp += 64
+		if exp + z >= 1074 { return 0 }
+	}
+	u = u << z | x.Uint64() >> (64 - z)
+	exp += z
+	if exp < 1022 {
+		return math.Float64frombits((1022 - exp) << 52 | u << 1 >> 12)
+	}
+	return math.Float64frombits(u >> (exp - 1022) >> 12)
+}
+
+// RandomReal returns a uniformly distributed pseudo-random float64 from [0, 1].
+// The distribution includes all floats, but may miss very few
+// subnormal floats in in [0, 2^-1022).
+// http://prng.di.unimi.it/random_real.c
+func (x *MCG) RandomReal() float64 {
+
+	u := x.Uint64()
+	z := uint64(bits.LeadingZeros64(u))
+	exp := uint64(64)
+	for u == 0 { 
+		u = x.Uint64() 
+		z = uint64(bits.LeadingZeros64(u))
+		exp += 64
+		if exp + z > 1074 + 64 { return 0 }
+	}
+	u = u << z | x.Uint64() >> (64 - z)
+	return ldexp(float64(u | 1), exp + z)
+}
+
+// Float64Bisect returns a uniformly distributed pseudo-random float64 value in [0, 1).
+// If round is true, rounding is applied and the range is [0, 1].
+// All floats, normal and subnormal, are included.
+func (x *MCG) Float64Bisect(round bool) float64 {
+
+	left, mean, right := 0.0, 0.5, 1.0
+	for {
+		u := x.Uint64()
+		for b := 0; b < 64; b++ {
+
+			if u & (1<<63) != 0 {
+				left = mean
+			} else {
+				right = mean
+			}
+			u <<= 1
+			mean = (left + right) / 2
+			if mean == left || mean == right {
+				if !round {
+					return left
+				}
+				if b == 63 {
+					u = x.Uint64()
+				}
+				if u & (1<<63) != 0 {
+					return right
+				} 
+				return left
+			}
+		}
+	}
+}
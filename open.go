@@ -0,0 +1,287 @@
+package prng
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Uniform floats from the open intervals (0, 1) and (-1, 1). The Open
+// functions are the [0, 1) functions of the same precision with 0 rejected,
+// so math.Log(u) and 1/u are finite. The Signed functions are a random sign
+// times an Open float of the same precision, which has full precision near 0
+// on both sides. The sign is the top bit of the first random word and the
+// magnitude is made of the remaining bits. Signed floats are not 0 either.
+
+// Float64Open returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution is 2^53 - 1 evenly spaced floats with spacing 2^-53.
+func (x *Xoro) Float64Open() float64 {
+	for {
+		if u := x.Xoroshiro128plus() >> 11; u != 0 {
+			return float64(u) * 0x1p-53
+		}
+	}
+}
+
+// Float64Open_64 returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution includes all floats in [2^-12, 1) and 2^52 - 1 evenly spaced
+// floats in (0, 2^-12) with spacing 2^-64.
+func (x *Xoro) Float64Open_64() float64 {
+
+	u := x.Uint64()
+	for u == 0 { u = x.Uint64() }
+	z := uint64(bits.LeadingZeros64(u)) + 1
+	return math.Float64frombits((1023 - z) << 52 | u << z >> 12)
+}
+
+// Float64Openfull returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution includes all floats in (0, 1).
+func (x *Xoro) Float64Openfull() float64 {
+	for {
+		if f := x.Float64full(); f != 0 {
+			return f
+		}
+	}
+}
+
+// Float64Signed returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution is 2^54 - 2 floats, the floats of Float64Open and their negatives.
+func (x *Xoro) Float64Signed() float64 {
+	for {
+		u := x.Xoroshiro128plus()
+		if m := u << 1 >> 11; m != 0 {
+			return math.Float64frombits(u >> 63 << 63 | math.Float64bits(float64(m) * 0x1p-53))
+		}
+	}
+}
+
+// Float64Signed_64 returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution includes all floats in (-1, -2^-11] and [2^-11, 1) and
+// 2^53 - 2 evenly spaced floats in (-2^-11, 2^-11) with spacing 2^-63, 0 excluded.
+func (x *Xoro) Float64Signed_64() float64 {
+
+	u := x.Uint64()
+	for u << 1 == 0 { u = x.Uint64() }
+	m := u << 1                                   // 63 random bits from the top
+	z := uint64(bits.LeadingZeros64(m)) + 1
+	return math.Float64frombits(u >> 63 << 63 | (1023 - z) << 52 | m << z >> 12)
+}
+
+// Float64Signedfull returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution includes all floats in (-1, 1) except 0.
+// Float64Signedfull is equivalent to Float64Openfull with a random sign.
+func (x *Xoro) Float64Signedfull() float64 {
+
+	u := x.Uint64()
+	m := u << 1
+	z := uint64(bits.LeadingZeros64(m)) + 1
+	if z <= 11 {                                 // 99.95% of cases
+		return math.Float64frombits(u >> 63 << 63 | (1023 - z) << 52 | m << z >> 12)
+	}
+	return math.Float64frombits(u >> 63 << 63 | math.Float64bits(x.openBelow(-11)))
+}
+
+// Float64Open returns a uniformly distributed pseudo-random float64 from (0, 1).
+// See Xoro.Float64Open.
+func (x *Xosh) Float64Open() float64 {
+	for {
+		if u := x.Xoshiro256plus() >> 11; u != 0 {
+			return float64(u) * 0x1p-53
+		}
+	}
+}
+
+// Float64Open_64 returns a uniformly distributed pseudo-random float64 from (0, 1).
+// See Xoro.Float64Open_64.
+func (x *Xosh) Float64Open_64() float64 {
+
+	u := x.Uint64()
+	for u == 0 { u = x.Uint64() }
+	z := uint64(bits.LeadingZeros64(u)) + 1
+	return math.Float64frombits((1023 - z) << 52 | u << z >> 12)
+}
+
+// Float64Openfull returns a uniformly distributed pseudo-random float64 from (0, 1).
+// See Xoro.Float64Openfull.
+func (x *Xosh) Float64Openfull() float64 {
+	for {
+		if f := x.Float64full(); f != 0 {
+			return f
+		}
+	}
+}
+
+// Float64Signed returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// See Xoro.Float64Signed.
+func (x *Xosh) Float64Signed() float64 {
+	for {
+		u := x.Xoshiro256plus()
+		if m := u << 1 >> 11; m != 0 {
+			return math.Float64frombits(u >> 63 << 63 | math.Float64bits(float64(m) * 0x1p-53))
+		}
+	}
+}
+
+// Float64Signed_64 returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// See Xoro.Float64Signed_64.
+func (x *Xosh) Float64Signed_64() float64 {
+
+	u := x.Uint64()
+	for u << 1 == 0 { u = x.Uint64() }
+	m := u << 1
+	z := uint64(bits.LeadingZeros64(m)) + 1
+	return math.Float64frombits(u >> 63 << 63 | (1023 - z) << 52 | m << z >> 12)
+}
+
+// Float64Signedfull returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// See Xoro.Float64Signedfull.
+func (x *Xosh) Float64Signedfull() float64 {
+
+	u := x.Uint64()
+	m := u << 1
+	z := uint64(bits.LeadingZeros64(m)) + 1
+	if z <= 11 {
+		return math.Float64frombits(u >> 63 << 63 | (1023 - z) << 52 | m << z >> 12)
+	}
+	return math.Float64frombits(u >> 63 << 63 | math.Float64bits(x.openBelow(-11)))
+}
+
+// Float64Open returns a uniformly distributed pseudo-random float64 from (0, 1).
+// See Xoro.Float64Open.
+func (x *MCG) Float64Open() float64 {
+	for {
+		if u := x.Uint64() >> 11; u != 0 {
+			return float64(u) * 0x1p-53
+		}
+	}
+}
+
+// Float64Open_64 returns a uniformly distributed pseudo-random float64 from (0, 1).
+// See Xoro.Float64Open_64.
+func (x *MCG) Float64Open_64() float64 {
+
+	u := x.Uint64()
+	for u == 0 { u = x.Uint64() }
+	z := uint64(bits.LeadingZeros64(u)) + 1
+	return math.Float64frombits((1023 - z) << 52 | u << z >> 12)
+}
+
+// Float64Openfull returns a uniformly distributed pseudo-random float64 from (0, 1).
+// See Xoro.Float64Openfull.
+func (x *MCG) Float64Openfull() float64 {
+	for {
+		if f := x.Float64full(); f != 0 {
+			return f
+		}
+	}
+}
+
+// Float64Signed returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// See Xoro.Float64Signed.
+func (x *MCG) Float64Signed() float64 {
+	for {
+		u := x.Uint64()
+		if m := u << 1 >> 11; m != 0 {
+			return math.Float64frombits(u >> 63 << 63 | math.Float64bits(float64(m) * 0x1p-53))
+		}
+	}
+}
+
+// Float64Signed_64 returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// See Xoro.Float64Signed_64.
+func (x *MCG) Float64Signed_64() float64 {
+
+	u := x.Uint64()
+	for u << 1 == 0 { u = x.Uint64() }
+	m := u << 1
+	z := uint64(bits.LeadingZeros64(m)) + 1
+	return math.Float64frombits(u >> 63 << 63 | (1023 - z) << 52 | m << z >> 12)
+}
+
+// Float64Signedfull returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// See Xoro.Float64Signedfull.
+func (x *MCG) Float64Signedfull() float64 {
+
+	u := x.Uint64()
+	m := u << 1
+	z := uint64(bits.LeadingZeros64(m)) + 1
+	if z <= 11 {
+		return math.Float64frombits(u >> 63 << 63 | (1023 - z) << 52 | m << z >> 12)
+	}
+	return math.Float64frombits(u >> 63 << 63 | math.Float64bits(x.openBelow(-11)))
+}
+
+// Float64Open returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution is 2^53 - 1 evenly spaced floats with spacing 2^-53.
+func (r *Prng) Float64Open() float64 {
+	return r.rng.Float64Open()
+}
+
+// Float64Open_64 returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution includes all floats in [2^-12, 1) and 2^52 - 1 evenly spaced
+// floats in (0, 2^-12) with spacing 2^-64.
+func (r *Prng) Float64Open_64() float64 {
+	return r.rng.Float64Open_64()
+}
+
+// Float64Openfull returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution includes all floats in (0, 1).
+func (r *Prng) Float64Openfull() float64 {
+	return r.rng.Float64Openfull()
+}
+
+// Float64Signed returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution is the floats of Float64Open and their negatives.
+func (r *Prng) Float64Signed() float64 {
+	return r.rng.Float64Signed()
+}
+
+// Float64Signed_64 returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution includes all floats in (-1, -2^-11] and [2^-11, 1) and evenly
+// spaced floats in (-2^-11, 2^-11) with spacing 2^-63, 0 excluded.
+func (r *Prng) Float64Signed_64() float64 {
+	return r.rng.Float64Signed_64()
+}
+
+// Float64Signedfull returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution includes all floats in (-1, 1) except 0.
+func (r *Prng) Float64Signedfull() float64 {
+	return r.rng.Float64Signedfull()
+}
+
+// Float64Open returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution is 2^53 - 1 evenly spaced floats with spacing 2^-53.
+func Float64Open() float64 {
+	return globalPrng.rng.Float64Open()
+}
+
+// Float64Open_64 returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution includes all floats in [2^-12, 1) and 2^52 - 1 evenly spaced
+// floats in (0, 2^-12) with spacing 2^-64.
+func Float64Open_64() float64 {
+	return globalPrng.rng.Float64Open_64()
+}
+
+// Float64Openfull returns a uniformly distributed pseudo-random float64 from (0, 1).
+// The distribution includes all floats in (0, 1).
+func Float64Openfull() float64 {
+	return globalPrng.rng.Float64Openfull()
+}
+
+// Float64Signed returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution is the floats of Float64Open and their negatives.
+func Float64Signed() float64 {
+	return globalPrng.rng.Float64Signed()
+}
+
+// Float64Signed_64 returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution includes all floats in (-1, -2^-11] and [2^-11, 1) and evenly
+// spaced floats in (-2^-11, 2^-11) with spacing 2^-63, 0 excluded.
+func Float64Signed_64() float64 {
+	return globalPrng.rng.Float64Signed_64()
+}
+
+// Float64Signedfull returns a uniformly distributed pseudo-random float64 from (-1, 1).
+// The distribution includes all floats in (-1, 1) except 0.
+func Float64Signedfull() float64 {
+	return globalPrng.rng.Float64Signedfull()
+}
@@ -0,0 +1,90 @@
+package prng
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64Open(t *testing.T) {
+	const n = 200000
+	xoro, xosh, mcg := NewXoro(1), NewXosh(1), NewMCG(1)
+	a := make([]float64, n)
+	for _, e := range []Engine{&xoro, &xosh, &mcg} {
+		for _, f := range []struct {
+			name   string
+			f      func() float64
+			signed bool
+		}{
+			{"Float64Open", e.Float64Open, false},
+			{"Float64Open_64", e.Float64Open_64, false},
+			{"Float64Openfull", e.Float64Openfull, false},
+			{"Float64Signed", e.Float64Signed, true},
+			{"Float64Signed_64", e.Float64Signed_64, true},
+			{"Float64Signedfull", e.Float64Signedfull, true},
+		} {
+			lo, neg := 0.0, 0
+			if f.signed {
+				lo = -1
+			}
+			for i := range a {
+				u := f.f()
+				if !(lo < u && u < 1) || u == 0 {
+					t.Fatalf("%T %s = %g", e, f.name, u)
+				}
+				if u < 0 {
+					neg++
+				}
+				a[i] = u
+			}
+			d := ksOneSample(a, func(x float64) float64 { return (x - lo) / (1 - lo) })
+			if d > 1.95 / math.Sqrt(n) {
+				t.Errorf("%T %s KS D = %f", e, f.name, d)
+			}
+			if f.signed && abs(float64(neg) - n/2) > 4 * math.Sqrt(n/4) {
+				t.Errorf("%T %s %d negatives of %d", e, f.name, neg, n)
+			}
+		}
+	}
+}
+
+// TestFloat64SignedNearZero tests that the Signed floats near 0 are uniform
+// on both sides down to the precision of the function.
+func TestFloat64SignedNearZero(t *testing.T) {
+	r := New(1)
+	for _, f := range []struct {
+		name string
+		f    func() float64
+		lim  float64
+	}{
+		{"Float64Signed_64", r.Float64Signed_64, 0x1p-11},
+		{"Float64Signedfull", r.Float64Signedfull, 0x1p-11},
+	} {
+		pos, neg := make([]float64, 0, 2000), make([]float64, 0, 2000)
+		for len(pos) < cap(pos) || len(neg) < cap(neg) {
+			u := f.f()
+			switch {
+			case 0 < u && u < f.lim && len(pos) < cap(pos):
+				pos = append(pos, u / f.lim)
+			case -f.lim < u && u < 0 && len(neg) < cap(neg):
+				neg = append(neg, -u / f.lim)
+			}
+		}
+		for _, a := range [][]float64{pos, neg} {
+			d := ksOneSample(a, func(x float64) float64 { return x })
+			if d > 1.95 / math.Sqrt(float64(len(a))) {
+				t.Errorf("%s near 0 KS D = %f", f.name, d)
+			}
+		}
+	}
+	// The smallest magnitudes of Float64Signedfull are of full precision.
+	x := NewXoro(1)
+	small := 0
+	for i := 0; i < 1 << 22; i++ {
+		if abs(x.Float64Signedfull()) < 0x1p-20 {
+			small++
+		}
+	}
+	if want := float64(1 << 22) * 0x1p-20; abs(float64(small) - want) > 5 * math.Sqrt(want) {
+		t.Errorf("Float64Signedfull %d magnitudes below 2^-20, want %g", small, want)
+	}
+}
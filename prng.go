@@ -7,18 +7,70 @@ import (
 
 // Random state sizes in bytes.
 const (
-	PrngStateSize = 16
-	XoroStateSize = 16
-	XoshStateSize = 32
+	PrngStateSize     = XoroStateSize
+	XoshPrngStateSize = XoshStateSize
+	MCGPrngStateSize  = MCGStateSize
+	XoroStateSize     = 16
+	XoshStateSize     = 32
+	MCGStateSize      = 8
+)
+
+// An Engine is the common method set of the generators Xoro, Xosh and MCG,
+// which are the generators of Prng, XoshPrng and MCGPrng.
+type Engine interface {
+	Seed(seed uint64)
+	Jump()
+	State() []byte
+	WriteState(b []byte)
+	ReadState(b []byte)
+	Uint64() uint64
+	Uint64n(n uint64) uint64
+	Float64() float64
+	Float64_64() float64
+	Float64_117() float64
+	Float64full() float64
+	RandomReal() float64
+	Float64Bisect(round bool) float64
+	Float64Range(a, b float64) float64
+	Float64RangeR(a, b float64) float64
+	Float64Open() float64
+	Float64Open_64() float64
+	Float64Openfull() float64
+	Float64Signed() float64
+	Float64Signed_64() float64
+	Float64Signedfull() float64
+	Float32() float32
+	Float32_32() float32
+	Float32full() float32
+	Float32fullR() float32
+	RandomReal32() float32
+	Float32Bisect(round bool) float32
+	NormFloat64() float64
+	ExpFloat64() float64
+	ExpFloat64Rate(lambda float64) float64
+	ExpFloat64full() float64
+}
+
+var (
+	_ Engine = (*Xoro)(nil)
+	_ Engine = (*Xosh)(nil)
+	_ Engine = (*MCG)(nil)
 )
 
 // A Prng is a wrapper around the actual pseudo-random number generator.
-// It is now fixed to xoroshiro128 generator instead of having more flexible rng
-// interface. This way we get faster inlineable functions, but cannot change
-// the rng in an application.
+// It is fixed to xoroshiro128 generator instead of having an Engine interface
+// field or a type parameter, because calls through them are not inlined.
+// XoshPrng and MCGPrng are the same wrapper around xoshiro256 and MCG
+// generators, and an application can use any of them side by side.
+// xoshprng.go and mcgprng.go are generated from the methods of Prng, and
+// xoshgen.go and mcggen.go from methods of Xoro.
+// A copy of a Prng has the same state and the same buffered bits of
+// Bernoulli, so a copy should be seeded or jumped before use.
+//
+//go:generate go run gen.go
 type Prng struct {
-	// rng Xosh	// xoshiro256+/** generator
 	rng Xoro // xoroshiro128+/** generator
+	buf bitBuffer
 }
 
 // New returns a new Prng seeded with the seed.
@@ -28,11 +80,6 @@ func New(seed uint64) Prng {
 	return r
 }
 
-// NewSource is only for math/rand compability.
-func NewSource(seed int64) uint64 {
-	return uint64(seed)
-}
-
 // Outlet is a delivery type of pseudo-random number generators with
 // non-overlapping random streams. Methods of Outlet use sync.Mutex
 // to protect the Outlet for simultaneous access.
@@ -40,7 +87,9 @@ type Outlet struct {
 	mu   sync.Mutex
 	xoro Xoro
 	xosh Xosh
-	rng Prng
+	mcg  MCG
+	mcgs int // the number of MCG streams delivered
+	rng  Prng
 }
 
 // NewOutlet returns a new generator delivery Outlet seeded by the seed.
@@ -48,6 +97,7 @@ func NewOutlet(seed uint64) *Outlet {
 	s := &Outlet{}
 	s.xoro.Seed(seed)
 	s.xosh.Seed(seed)
+	s.mcg.Seed(seed)
 	s.rng.Seed(seed)
 	return s
 }
@@ -89,17 +139,26 @@ func Next() Prng {
 }
 
 // NextXosh returns the next non-overlapping stream xoshiro256 from
-// globalOutlet. This generator has only Float64 and Uint64 prn methods.
+// globalOutlet. The generator has the methods of Engine. XoshPrng is
+// xoshiro256 with all the methods of Prng.
 func NextXosh() Xosh {
 	return global.outlet.NextXosh()
 }
 
 // NextXoro returns the next non-overlapping stream xoroshiro128 from
-// globalOutlet. This generator has only Float64 and Uint64 prn methods.
+// globalOutlet. The generator has the methods of Engine. Prng is
+// xoroshiro128 with more methods.
 func NextXoro() Xoro {
 	return global.outlet.NextXoro()
 }
 
+// NextMCG returns the next non-overlapping stream MCG from globalOutlet.
+// The generator has the methods of Engine. MCGPrng is MCG with all
+// the methods of Prng.
+func NextMCG() MCG {
+	return global.outlet.NextMCG()
+}
+
 // NewPrngSlice returns a slice of n Rands with non-overlapping
 // random streams. The first Prng is seeded by seed.
 func NewPrngSlice(n int, seed uint64) []Prng {
@@ -112,13 +171,14 @@ func NewPrngSlice(n int, seed uint64) []Prng {
 	return s
 }
 
-// A Prng's rng has methods Float64, Uint64, Jump and Seed.
+// A Prng's rng has the methods of Engine.
 // Prng & math/rand functions are defined below.
 
 // Seed seeds a Prng by the seed. Any seed is ok.
 // Do not seed Rands created by Next or NewPrngSlice.
 func (r *Prng) Seed(seed uint64) {
 	r.rng.Seed(seed)
+	r.buf = bitBuffer{}
 }
 
 // Jump sets r to the same state as 2^64 calls to r.Uint64.
@@ -126,6 +186,7 @@ func (r *Prng) Seed(seed uint64) {
 // parallel computation.
 func (r *Prng) Jump() {
 	r.rng.Jump()
+	r.buf = bitBuffer{}
 }
 
 // State returns the current state of the generator r as []byte.
@@ -142,6 +203,7 @@ func (r *Prng) WriteState(b []byte) {
 // r.ReadState(r.State()) changes nothing.
 func (r *Prng) ReadState(b []byte) {
 	r.rng.ReadState(b)
+	r.buf = bitBuffer{}
 }
 
 // Float64 returns a uniformly distributed pseudo-random float64 from [0, 1).
@@ -199,29 +261,60 @@ func (r *Prng) Int() int {
 	return int(r.rng.Uint64() >> 1)
 }
 
-// Uint64n returns a pseudo-random number in [0,n) as an uint64.
-// Uint64n doesn't make any bias correction. The bias with 64-bit numbers is very small
-// and propably not detectable from the random stream.
+// Uint64n returns an unbiased pseudo-random number in [0,n) as an uint64.
+// It panics if n == 0.
 func (r *Prng) Uint64n(n uint64) uint64 {
 	if n == 0 {
 		panic("invalid argument to Uint64n")
 	}
-	return r.rng.Uint64() % n
+	return r.rng.uint64n(n)
 }
 
-// Int63n return a pseudo-random number in [0,n) as an int64.
+// Int63n return an unbiased pseudo-random number in [0,n) as an int64.
+// It panics if n <= 0.
 func (r *Prng) Int63n(n int64) int64 {
 	if n <= 0 {
-		panic("invalid argument to Int64n")
+		panic("invalid argument to Int63n")
 	}
-	return int64((r.rng.Uint64() % uint64(n)) &^ (1 << 63))
+	return int64(r.rng.uint64n(uint64(n)))
 }
 
-// Intn returns a pseudo-random number in [0,n) as an int.
+// Intn returns an unbiased pseudo-random number in [0,n) as an int.
+// It panics if n <= 0.
 func (r *Prng) Intn(n int) int {
 	if n <= 0 {
 		panic("invalid argument to Intn")
 	}
+	return int(r.rng.uint64n(uint64(n)))
+}
+
+// Uint64nBiased returns a pseudo-random number in [0,n) as an uint64.
+// Uint64nBiased doesn't make any bias correction. The bias with 64-bit numbers
+// is very small and propably not detectable from the random stream for small n.
+// The numbers in [0, 2^64 % n) have probability ceil(2^64/n) / 2^64 and the rest
+// floor(2^64/n) / 2^64. It panics if n == 0.
+func (r *Prng) Uint64nBiased(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64nBiased")
+	}
+	return r.rng.Uint64() % n
+}
+
+// Int63nBiased return a pseudo-random number in [0,n) as an int64
+// without bias correction. It panics if n <= 0.
+func (r *Prng) Int63nBiased(n int64) int64 {
+	if n <= 0 {
+		panic("invalid argument to Int63nBiased")
+	}
+	return int64((r.rng.Uint64() % uint64(n)) &^ (1 << 63))
+}
+
+// IntnBiased returns a pseudo-random number in [0,n) as an int
+// without bias correction. It panics if n <= 0.
+func (r *Prng) IntnBiased(n int) int {
+	if n <= 0 {
+		panic("invalid argument to IntnBiased")
+	}
 	return int((r.rng.Uint64() % uint64(n)) &^ (1 << 63))
 }
 
@@ -234,7 +327,7 @@ var globalPrng = New(uint64(time.Now().UnixNano()))
 
 // Seed seeds system global generator globalPrng by seed.
 func Seed(seed uint64) {
-	globalPrng.rng.Seed(seed)
+	globalPrng.Seed(seed)
 }
 
 // Float64 returns a uniformly distributed pseudo-random float64 from [0, 1).
@@ -292,25 +385,40 @@ func Int() int {
 	return int(globalPrng.rng.Uint64() >> 1)
 }
 
-// Uint64n returns a pseudo-random number in [0,n) as an uint64.
+// Uint64n returns an unbiased pseudo-random number in [0,n) as an uint64.
+// It panics if n == 0.
 func Uint64n(n uint64) uint64 {
-	return globalPrng.rng.Uint64() % n
+	return globalPrng.Uint64n(n)
 }
 
-// Int63n return a pseudo-random number in [0,n) as an int64
+// Int63n return an unbiased pseudo-random number in [0,n) as an int64.
+// It panics if n <= 0.
 func Int63n(n int64) int64 {
-	if n <= 0 {
-		panic("invalid argument to Int63n")
-	}
-	return int64((globalPrng.rng.Uint64() % uint64(n)) &^ (1 << 63))
+	return globalPrng.Int63n(n)
 }
 
-// Intn returns a pseudo-random number in [0,n)  as an int.
+// Intn returns an unbiased pseudo-random number in [0,n) as an int.
+// It panics if n <= 0.
 func Intn(n int) int {
-	if n <= 0 {
-		panic("invalid argument to Intn")
-	}
-	return int((globalPrng.rng.Uint64() % uint64(n)) &^ (1 << 63))
+	return globalPrng.Intn(n)
+}
+
+// Uint64nBiased returns a pseudo-random number in [0,n) as an uint64
+// without bias correction. It panics if n == 0.
+func Uint64nBiased(n uint64) uint64 {
+	return globalPrng.Uint64nBiased(n)
+}
+
+// Int63nBiased return a pseudo-random number in [0,n) as an int64
+// without bias correction. It panics if n <= 0.
+func Int63nBiased(n int64) int64 {
+	return globalPrng.Int63nBiased(n)
+}
+
+// IntnBiased returns a pseudo-random number in [0,n) as an int
+// without bias correction. It panics if n <= 0.
+func IntnBiased(n int) int {
+	return globalPrng.IntnBiased(n)
 }
 
 // Splitmix is a 64-bit state SplitMix64 pseudo-random number generator
@@ -0,0 +1,134 @@
+package prng
+
+import (
+	randv2 "math/rand/v2"
+)
+
+// math/rand/v2 compatibility.
+// Xoro, Xosh, MCG, Prng, XoshPrng and MCGPrng pointers are math/rand/v2
+// Sources and can be used by randv2.New(&x). The bounded integer functions below are the
+// same as in math/rand/v2 and return the same numbers as a randv2.Rand
+// using the same generator.
+
+var (
+	_ randv2.Source = (*Prng)(nil)
+	_ randv2.Source = (*XoshPrng)(nil)
+	_ randv2.Source = (*MCGPrng)(nil)
+	_ randv2.Source = (*Xoro)(nil)
+	_ randv2.Source = (*Xosh)(nil)
+	_ randv2.Source = (*MCG)(nil)
+)
+
+// intType is the set of integer types of N.
+type intType interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// uint64n returns an unbiased pseudo-random number in [0,n)
+// without the argument check.
+func (r *Prng) uint64n(n uint64) uint64 {
+	return r.rng.uint64n(n)
+}
+
+// IntN returns a pseudo-random number in [0,n) as an int.
+// It panics if n <= 0.
+func (r *Prng) IntN(n int) int {
+	if n <= 0 {
+		panic("invalid argument to IntN")
+	}
+	return int(r.uint64n(uint64(n)))
+}
+
+// Int32N returns a pseudo-random number in [0,n) as an int32.
+// It panics if n <= 0.
+func (r *Prng) Int32N(n int32) int32 {
+	if n <= 0 {
+		panic("invalid argument to Int32N")
+	}
+	return int32(r.uint64n(uint64(n)))
+}
+
+// Int64N returns a pseudo-random number in [0,n) as an int64.
+// It panics if n <= 0.
+func (r *Prng) Int64N(n int64) int64 {
+	if n <= 0 {
+		panic("invalid argument to Int64N")
+	}
+	return int64(r.uint64n(uint64(n)))
+}
+
+// UintN returns a pseudo-random number in [0,n) as an uint.
+// It panics if n == 0.
+func (r *Prng) UintN(n uint) uint {
+	if n == 0 {
+		panic("invalid argument to UintN")
+	}
+	return uint(r.uint64n(uint64(n)))
+}
+
+// Uint32N returns a pseudo-random number in [0,n) as an uint32.
+// It panics if n == 0.
+func (r *Prng) Uint32N(n uint32) uint32 {
+	if n == 0 {
+		panic("invalid argument to Uint32N")
+	}
+	return uint32(r.uint64n(uint64(n)))
+}
+
+// Uint64N returns a pseudo-random number in [0,n) as an uint64.
+// It panics if n == 0.
+func (r *Prng) Uint64N(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64N")
+	}
+	return r.uint64n(n)
+}
+
+// The top level functions using globalPrng.
+
+// N returns a pseudo-random number in [0,n). The type parameter Int can
+// be any integer type. It panics if n <= 0.
+// N is a top level function only, because methods cannot have type parameters.
+func N[Int intType](n Int) Int {
+	if n <= 0 {
+		panic("invalid argument to N")
+	}
+	return Int(globalPrng.uint64n(uint64(n)))
+}
+
+// IntN returns a pseudo-random number in [0,n) as an int.
+// It panics if n <= 0.
+func IntN(n int) int {
+	return globalPrng.IntN(n)
+}
+
+// Int32N returns a pseudo-random number in [0,n) as an int32.
+// It panics if n <= 0.
+func Int32N(n int32) int32 {
+	return globalPrng.Int32N(n)
+}
+
+// Int64N returns a pseudo-random number in [0,n) as an int64.
+// It panics if n <= 0.
+func Int64N(n int64) int64 {
+	return globalPrng.Int64N(n)
+}
+
+// UintN returns a pseudo-random number in [0,n) as an uint.
+// It panics if n == 0.
+func UintN(n uint) uint {
+	return globalPrng.UintN(n)
+}
+
+// Uint32N returns a pseudo-random number in [0,n) as an uint32.
+// It panics if n == 0.
+func Uint32N(n uint32) uint32 {
+	return globalPrng.Uint32N(n)
+}
+
+// Uint64N returns a pseudo-random number in [0,n) as an uint64.
+// It panics if n == 0.
+func Uint64N(n uint64) uint64 {
+	return globalPrng.Uint64N(n)
+}
@@ -0,0 +1,49 @@
+package prng
+
+import (
+	randv2 "math/rand/v2"
+	"testing"
+)
+
+func TestRandv2Equal(t *testing.T) {
+	// The same generator through math/rand/v2 gives the same numbers.
+	r := New(1)
+	s := New(1)
+	v := randv2.New(&s)
+	ns := []uint64{1, 2, 3, 7, 10, 1000, 1<<32 + 1, 1<<62 + 1, 1<<63 + 1, 1<<64 - 1}
+	for i := 0; i < 10000; i++ {
+		n := ns[i%len(ns)]
+		if r.Uint64N(n) != v.Uint64N(n) {
+			t.Fatalf("Uint64N(%d) differs from math/rand/v2", n)
+		}
+		if n < 1<<31 {
+			if r.IntN(int(n)) != v.IntN(int(n)) {
+				t.Fatalf("IntN(%d) differs from math/rand/v2", n)
+			}
+			if r.Uint32N(uint32(n)) != v.Uint32N(uint32(n)) {
+				t.Fatalf("Uint32N(%d) differs from math/rand/v2", n)
+			}
+			if r.Int32N(int32(n)) != v.Int32N(int32(n)) {
+				t.Fatalf("Int32N(%d) differs from math/rand/v2", n)
+			}
+		}
+		if n < 1<<63 && r.Int64N(int64(n)) != v.Int64N(int64(n)) {
+			t.Fatalf("Int64N(%d) differs from math/rand/v2", n)
+		}
+	}
+}
+
+func TestN(t *testing.T) {
+	const rounds = 1000000
+	const cells = 10
+	var tab [cells]int
+	for i := 0; i < rounds; i++ {
+		tab[N(int8(cells))]++
+	}
+	expected := rounds / cells
+	for i := 0; i < cells; i++ {
+		if abs(float64(tab[i]-expected)/float64(expected)) > 1e-2 {
+			t.Errorf("N: %d %d", i, tab[i])
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package prng
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Uniform floats over an interval [a, b). A uniform real U in [a, b) is
+// truncated to the float x <= U below it, so every float x in [a, b) has the
+// probability (next(x) - x) / (b - a) of the real interval it represents. With
+// rounding U is rounded to the nearest float in [a, b].
+//
+// In an interval [lo, hi), 0 <= lo, all the spacings of the floats are
+// multiples of the spacing g at lo. If hi/g < 2^64, U is an integer count
+// of g drawn by Uint64n and truncated to 53 significant bits. Otherwise
+// hi > 2^11 lo and U is drawn with full precision from [0, 2^e) >= [0, hi),
+// like Float64full, and rejected outside [lo, hi) with probability below 1/2.
+// A negative interval is sampled as the mirror image and an interval around 0
+// from [-max(-a, b), max(-a, b)) with rejection. The rounding variant draws
+// a truncated x and rounds up to next(x) by a random bit, because the real
+// U is uniform in [x, next(x)).
+//
+// The idea of exact interval sampling is from Goualard: Drawing random
+// floating-point numbers from an interval, https://hal.science/hal-03282794.
+//
+// The methods of Xoro in this file are copied to Xosh and MCG by gen.go.
+
+// Float64Range returns a uniformly distributed pseudo-random float64 from [a, b).
+// The distribution includes all floats in [a, b), each with the probability
+// of the real interval [x, next(x)) it represents. It panics if !(a < b)
+// or a or b is infinite.
+func (x *Xoro) Float64Range(a, b float64) float64 {
+	if !(a < b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		panic("invalid argument to Float64Range")
+	}
+	switch {
+	case a >= 0:
+		return x.positiveRange(a, b)
+	case b <= 0:
+		return -math.Nextafter(x.positiveRange(-b, -a), math.Inf(1))
+	}
+	m := math.Max(-a, b)
+	for {
+		neg := x.Uint64() >> 63 == 1
+		w := x.positiveRange(0, m)
+		if !neg {
+			if w < b {
+				return w
+			}
+			continue
+		}
+		if w = math.Nextafter(w, m); w <= -a {
+			return -w
+		}
+	}
+}
+
+// Float64RangeR returns a uniformly distributed pseudo-random float64 from
+// [a, b] using rounding. The distribution includes all floats in [a, b].
+func (x *Xoro) Float64RangeR(a, b float64) float64 {
+	w := x.Float64Range(a, b)
+	if x.Uint64() >> 63 == 1 {
+		w = math.Nextafter(w, math.Inf(1))
+	}
+	if w == 0 {
+		return 0                       // not -0
+	}
+	return w
+}
+
+// positiveRange returns a uniform float64 in [lo, hi), 0 <= lo < hi,
+// by truncation.
+func (x *Xoro) positiveRange(lo, hi float64) float64 {
+	bl, bh := math.Float64bits(lo) &^ (1 << 63), math.Float64bits(hi) // lo may be -0
+	el, eh := max(bl >> 52, 1), max(bh >> 52, 1) // biased exponents, 1 for subnormals
+	if eh - el <= 11 {
+		// lo = l g and hi = h g < 2^64 g, g = 2^(el-1075) the spacing at lo.
+		l := bl & (1<<52 - 1) | min(bl >> 52, 1) << 52
+		h := (bh & (1<<52 - 1) | min(bh >> 52, 1) << 52) << (eh - el)
+		n := l + x.Uint64n(h - l)
+		sh := uint64(max(bits.Len64(n) - 53, 0)) // truncated to 53 bits
+		return math.Float64frombits(n >> sh + (el - 1 + sh) << 52)
+	}
+	e := int(eh) - 1022
+	if bh & (1<<52 - 1) == 0 {
+		e--                            // hi is a power of 2
+	}
+	for {
+		w := x.fullBelow(e)
+		if w >= lo && w < hi {
+			return w
+		}
+	}
+}
+
+// fullBelow returns a uniform float64 in [0, 2^e) by truncation, all floats
+// included, -1020 <= e <= 1024. The binade below the top is chosen by the
+// count of leading zero bits as in Float64full.
+func (x *Xoro) fullBelow(e int) float64 {
+	top := 1022 + e                    // biased exponent of the top binade
+	u := x.Uint64()
+	z := bits.LeadingZeros64(u)
+	if z <= 11 && z < top {            // the mantissa from the same word
+		return math.Float64frombits(uint64(top - z) << 52 | u << (z + 1) >> 12)
+	}
+	for u == 0 && z < top {
+		u = x.Uint64()
+		z += bits.LeadingZeros64(u)
+	}
+	if z >= top {                      // subnormal floats in [0, 2^-1022)
+		return math.Float64frombits(x.Uint64() >> 12)
+	}
+	return math.Float64frombits(uint64(top - z) << 52 | x.Uint64() >> 12)
+}
+
+// openBelow returns a uniform float64 in (0, 2^e) by truncation, all floats
+// included. See fullBelow.
+func (x *Xoro) openBelow(e int) float64 {
+	for {
+		if f := x.fullBelow(e); f != 0 {
+			return f
+		}
+	}
+}
+
+// Float64Range returns a uniformly distributed pseudo-random float64 from [a, b).
+// The distribution includes all floats in [a, b), each with the probability
+// of the real interval [x, next(x)) it represents. It panics if !(a < b)
+// or a or b is infinite.
+func (r *Prng) Float64Range(a, b float64) float64 {
+	return r.rng.Float64Range(a, b)
+}
+
+// Float64RangeR returns a uniformly distributed pseudo-random float64 from
+// [a, b] using rounding. The distribution includes all floats in [a, b].
+func (r *Prng) Float64RangeR(a, b float64) float64 {
+	return r.rng.Float64RangeR(a, b)
+}
+
+// Float64Range returns a uniformly distributed pseudo-random float64 from [a, b).
+// The distribution includes all floats in [a, b). It panics if !(a < b)
+// or a or b is infinite.
+func Float64Range(a, b float64) float64 {
+	return globalPrng.rng.Float64Range(a, b)
+}
+
+// Float64RangeR returns a uniformly distributed pseudo-random float64 from
+// [a, b] using rounding. The distribution includes all floats in [a, b].
+func Float64RangeR(a, b float64) float64 {
+	return globalPrng.rng.Float64RangeR(a, b)
+}
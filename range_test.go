@@ -0,0 +1,198 @@
+package prng
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// float64BisectRange returns a uniform float64 in [a, b) or with rounding
+// in [a, b] by bisecting the real interval [a, b) exactly by random bits
+// until it is inside a single float. It is a slow reference for the
+// interval functions.
+func float64BisectRange(x Engine, a, b float64, round bool) float64 {
+	const prec = 4200 // exact down to the spacing 2^-1074 over the float range
+	left := new(big.Float).SetPrec(prec).SetFloat64(a)
+	right := new(big.Float).SetPrec(prec).SetFloat64(b)
+	mid := new(big.Float).SetPrec(prec)
+	for {
+		u := x.Uint64()
+		for i := 0; i < 64; i++ {
+			mid.Add(left, right).SetMantExp(mid, -1)
+			if u & (1<<63) != 0 {
+				left.Set(mid)
+			} else {
+				right.Set(mid)
+			}
+			u <<= 1
+			var l, r float64
+			if round {
+				l, _ = left.Float64()
+				r, _ = right.Float64()
+			} else {
+				l, r = floorFloat(left), floorFloat(right)
+				if r != l && new(big.Float).SetFloat64(r).Cmp(right) == 0 {
+					r = math.Nextafter(r, math.Inf(-1))   // right is excluded
+				}
+			}
+			if l == r {
+				if l == 0 {
+					return 0           // not -0
+				}
+				return l
+			}
+		}
+	}
+}
+
+// floorFloat returns the largest float64 <= v.
+func floorFloat(v *big.Float) float64 {
+	f, _ := v.Float64()
+	if new(big.Float).SetFloat64(f).Cmp(v) > 0 {
+		f = math.Nextafter(f, math.Inf(-1))
+	}
+	return f
+}
+
+// floatsPmf returns the floats in [a, b) or with round in [a, b] and their
+// exact probabilities under truncation or rounding of a uniform real.
+func floatsPmf(a, b float64, round bool) ([]float64, []float64) {
+	var fs, pmf []float64
+	up := math.Inf(1)
+	for x := a; x < b; x = math.Nextafter(x, up) {
+		fs = append(fs, x)
+	}
+	w := b - a
+	for _, x := range fs {
+		p := (math.Nextafter(x, up) - x) / w
+		if round {
+			p = p / 2
+			if x > a {
+				p += (x - math.Nextafter(x, -up)) / w / 2
+			}
+		}
+		pmf = append(pmf, p)
+	}
+	if round {
+		fs = append(fs, b)
+		pmf = append(pmf, (b - math.Nextafter(b, -up)) / w / 2)
+	}
+	return fs, pmf
+}
+
+// testFloatsPmf tests the samples of f against the exact pmf by chi-square.
+func testFloatsPmf(t *testing.T, name string, n int, a, b float64, round bool, f func() float64) {
+	fs, pmf := floatsPmf(a, b, round)
+	index := make(map[float64]int, len(fs))
+	for i, x := range fs {
+		index[x] = i
+	}
+	counts := make([]float64, len(fs))
+	for range n {
+		x := f()
+		i, ok := index[x]
+		if !ok || x == 0 && math.Signbit(x) {
+			t.Fatalf("%s [%g, %g): %g out of range", name, a, b, x)
+		}
+		counts[i]++
+	}
+	chi2, df := 0.0, -1.0
+	obs, exp := 0.0, 0.0
+	for i := range pmf {
+		obs += counts[i]
+		exp += pmf[i] * float64(n)
+		if exp >= 20 || i == len(pmf)-1 {
+			chi2 += (obs - exp) * (obs - exp) / exp
+			df++
+			obs, exp = 0, 0
+		}
+	}
+	if lim := df + 5 * math.Sqrt(2 * df); chi2 > lim {
+		t.Errorf("%s [%g, %g) round %v: chi2 = %f > %f, df %0.f", name, a, b, round, chi2, lim, df)
+	}
+}
+
+var rangeCases = [][2]float64{
+	{1, 1 + 0x1p-48},                               // one binade
+	{1 - 0x1p-49, 1 + 0x1p-48},                     // two binades
+	{-1 - 0x1p-48, -1 + 0x1p-50},                   // negative
+	{-5 * 0x1p-1074, 7 * 0x1p-1074},                // around 0, subnormal
+	{0, 3 * 0x1p-1074},
+	{-3 * 0x1p-1074, 0},
+}
+
+func TestFloat64Range(t *testing.T) {
+	const rounds = 300000
+	x, r := NewXosh(1), New(1)
+	for _, c := range rangeCases {
+		a, b := c[0], c[1]
+		testFloatsPmf(t, "Xosh.Float64Range", rounds, a, b, false, func() float64 { return x.Float64Range(a, b) })
+		testFloatsPmf(t, "Prng.Float64RangeR", rounds, a, b, true, func() float64 { return r.Float64RangeR(a, b) })
+	}
+}
+
+func TestFloat64BisectRangeOracle(t *testing.T) {
+	const rounds = 20000
+	x := NewMCG(1)
+	for _, c := range rangeCases {
+		a, b := c[0], c[1]
+		for _, round := range []bool{false, true} {
+			testFloatsPmf(t, "float64BisectRange", rounds, a, b, round, func() float64 {
+				return float64BisectRange(&x, a, b, round)
+			})
+		}
+	}
+}
+
+// TestFloat64RangeWide tests the intervals sampled by rejection from [0, 2^e).
+// The binades must have probabilities by their lengths and the lowest mantissa
+// bits must be random also in the small numbers.
+func TestFloat64RangeWide(t *testing.T) {
+	const rounds = 1000000
+	x := NewXoro(1)
+	for _, c := range [][2]float64{{0, 1}, {3, 0x1p20}, {-1e10, 5e9}, {-7, -0x1p-100}, {0, 1e300}} {
+		a, b := c[0], c[1]
+		s := make([]float64, rounds)
+		oracle := make([]float64, rounds / 200)
+		small, odd := 0.0, 0.0
+		for i := range s {
+			v := x.Float64Range(a, b)
+			if !(v >= a && v < b) {
+				t.Fatalf("Float64Range(%g, %g) = %g", a, b, v)
+			}
+			if abs(v) < math.Max(abs(a), abs(b)) * 0x1p-8 {
+				small++
+				odd += float64(math.Float64bits(v) & 1)
+			}
+			s[i] = v
+		}
+		for i := range oracle {
+			oracle[i] = float64BisectRange(&x, a, b, false)
+		}
+		if f := odd / small; small > 1000 && abs(f - 0.5) > 0.05 {
+			t.Errorf("Float64Range(%g, %g): odd fraction %f of small values", a, b, f)
+		}
+		d := ksTwoSample(s, oracle)
+		if lim := ksLimit(len(s), len(oracle)); d > lim {
+			t.Errorf("Float64Range(%g, %g): KS D = %f > %f to float64BisectRange", a, b, d, lim)
+		}
+		d = ksOneSample(s, func(v float64) float64 { return (v - a) / (b - a) })
+		if lim := 1.95 / math.Sqrt(rounds); d > lim {
+			t.Errorf("Float64Range(%g, %g): KS D = %f > %f", a, b, d, lim)
+		}
+	}
+}
+
+func TestFloat64RangePanics(t *testing.T) {
+	inf := math.Inf(1)
+	for _, c := range [][2]float64{{1, 1}, {2, 1}, {math.NaN(), 1}, {0, inf}, {-inf, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Float64Range(%g, %g) did not panic", c[0], c[1])
+				}
+			}()
+			Float64Range(c[0], c[1])
+		}()
+	}
+}
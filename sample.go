@@ -0,0 +1,185 @@
+package prng
+
+import (
+	"math"
+	"slices"
+)
+
+// Sampling k of n without replacement in O(k) memory. The sample is sorted
+// ascending in dst[:k]. Small samples are drawn by Floyd's algorithm with
+// the membership test by a linear search of the sample and sorted. Larger
+// samples of n <= 2^53 are drawn sequentially in ascending order by Vitter's
+// Algorithm D, which draws the skip to the next sample from a continuous
+// proposal and accepts it by rejection. The skips are computed in float64,
+// so n > 2^53 uses Floyd's algorithm with a set of the sample. Vitter:
+// An Efficient Algorithm for Sequential Random Sampling,
+// https://doi.org/10.1145/23002.23003. Bentley & Floyd: A Sample of
+// Brilliance, https://doi.org/10.1145/30401.315746. The integers are drawn
+// by the unbiased uint64n.
+//
+// The methods of Xoro in this file are copied to Xosh and MCG by gen.go.
+
+const (
+	floydMax       = 32      // Floyd's algorithm with linear search for k <= floydMax
+	vitterMaxN     = 1 << 53 // Algorithm D for n <= vitterMaxN
+	vitterAlphaInv = 13      // Algorithm A if n <= 13 k
+)
+
+// Sample sets dst[:k] to k distinct pseudo-random numbers from [0, n) in
+// ascending order, a uniformly distributed sample of k of n without
+// replacement. Sample uses O(k) memory for any n. It panics if k < 0,
+// k > n or len(dst) < k.
+func (x *Xoro) Sample(n uint64, k int, dst []uint64) {
+	if k < 0 || uint64(k) > n || len(dst) < k {
+		panic("invalid argument to Sample")
+	}
+	dst = dst[:k]
+	switch {
+	case k == 0:
+	case k <= floydMax:
+		x.floyd(n, dst)
+		for i := 1; i < k; i++ {                 // insertion sort
+			for j := i; j > 0 && dst[j] < dst[j - 1]; j-- {
+				dst[j], dst[j - 1] = dst[j - 1], dst[j]
+			}
+		}
+	case n > vitterMaxN:
+		x.floydSet(n, dst)
+		slices.Sort(dst)
+	default:
+		x.vitterD(n, dst)
+	}
+}
+
+// floyd sets dst to a sample of len(dst) of [0, n) by Floyd's algorithm.
+// For j = n-k, ..., n-1 a t uniform in [0, j] is added, or j if t is
+// already in the sample.
+func (x *Xoro) floyd(n uint64, dst []uint64) {
+	k := uint64(len(dst))
+	for i, j := 0, n - k; j < n; i, j = i + 1, j + 1 {
+		t := x.uint64n(j + 1)
+		if slices.Contains(dst[:i], t) {
+			t = j
+		}
+		dst[i] = t
+	}
+}
+
+// floydSet is floyd with a set for the membership test.
+func (x *Xoro) floydSet(n uint64, dst []uint64) {
+	k := uint64(len(dst))
+	set := make(map[uint64]struct{}, k)
+	for i, j := 0, n - k; j < n; i, j = i + 1, j + 1 {
+		t := x.uint64n(j + 1)
+		if _, ok := set[t]; ok {
+			t = j
+		}
+		set[t] = struct{}{}
+		dst[i] = t
+	}
+}
+
+// vitterD sets dst to a sample of len(dst) of [0, n), n <= 2^53, in
+// ascending order by Vitter's Algorithm D. The remaining sample is
+// drawn by Algorithm A when n <= 13 k.
+func (x *Xoro) vitterD(n uint64, dst []uint64) {
+	k := uint64(len(dst))
+	i, next := 0, uint64(0)                      // next is the first index not skipped
+	nr, kr := float64(n), float64(k)
+	kinv := 1 / kr
+	vprime := math.Exp(math.Log(x.Float64Open()) * kinv)
+	qu1 := n - k + 1
+	qu1r := float64(qu1)
+	for k > 1 && vitterAlphaInv * k < n {
+		kmin1inv := 1 / (kr - 1)
+		var s uint64
+		for {
+			var xr float64
+			for {                                // D2: the continuous proposal X
+				xr = nr * (1 - vprime)
+				s = uint64(xr)
+				if s < qu1 {
+					break
+				}
+				vprime = math.Exp(math.Log(x.Float64Open()) * kinv)
+			}
+			u := x.Float64Open()
+			sr := float64(s)
+			y1 := math.Exp(math.Log(u * nr / qu1r) * kmin1inv)
+			vprime = y1 * (1 - xr / nr) * (qu1r / (qu1r - sr))
+			if vprime <= 1 {                     // D3: the squeeze accepts
+				break
+			}
+			y2, top := 1.0, nr - 1               // D4: the exact test
+			var bottom float64
+			var limit uint64
+			if k - 1 > s {
+				bottom, limit = nr - kr, n - s
+			} else {
+				bottom, limit = nr - sr - 1, qu1
+			}
+			for t := n - 1; t >= limit; t-- {
+				y2 = y2 * top / bottom
+				top--
+				bottom--
+			}
+			if nr / (nr - xr) >= y1 * math.Exp(math.Log(y2) * kmin1inv) {
+				vprime = math.Exp(math.Log(x.Float64Open()) * kmin1inv)
+				break
+			}
+			vprime = math.Exp(math.Log(x.Float64Open()) * kinv)
+		}
+		next += s
+		dst[i] = next
+		i, next = i + 1, next + 1
+		n, nr = n - s - 1, nr - float64(s) - 1
+		k, kr = k - 1, kr - 1
+		kinv = kmin1inv
+		qu1, qu1r = qu1 - s, qu1r - float64(s)
+	}
+	x.vitterA(n, dst[i:], next)
+}
+
+// vitterA sets dst to a sample of len(dst) of [0, n) plus offset in
+// ascending order by Vitter's Algorithm A in O(n) time.
+func (x *Xoro) vitterA(n uint64, dst []uint64, offset uint64) {
+	k := len(dst)
+	nr := float64(n)
+	top := float64(n) - float64(k)
+	i := 0
+	for ; k - i >= 2; i++ {
+		v := x.Float64Open()
+		s := uint64(0)
+		quot := top / nr
+		for quot > v {                           // P(skip > s) = quot
+			s++
+			top--
+			nr--
+			quot = quot * top / nr
+		}
+		offset += s
+		dst[i] = offset
+		offset++
+		nr--
+		n -= s + 1
+	}
+	if i < k {
+		dst[i] = offset + x.uint64n(n)
+	}
+}
+
+// Sample sets dst[:k] to k distinct pseudo-random numbers from [0, n) in
+// ascending order, a uniformly distributed sample of k of n without
+// replacement. Sample uses O(k) memory for any n. It panics if k < 0,
+// k > n or len(dst) < k.
+func (r *Prng) Sample(n uint64, k int, dst []uint64) {
+	r.rng.Sample(n, k, dst)
+}
+
+// Sample sets dst[:k] to k distinct pseudo-random numbers from [0, n) in
+// ascending order, a uniformly distributed sample of k of n without
+// replacement. Sample uses O(k) memory for any n. It panics if k < 0,
+// k > n or len(dst) < k.
+func Sample(n uint64, k int, dst []uint64) {
+	globalPrng.Sample(n, k, dst)
+}
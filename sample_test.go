@@ -0,0 +1,112 @@
+package prng
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+// chiSquareUniform reports an error if the counts are not uniform by
+// the chi-square test.
+func chiSquareUniform(t *testing.T, name string, counts []int) {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	exp := float64(total) / float64(len(counts))
+	chi2 := 0.0
+	for _, c := range counts {
+		chi2 += (float64(c) - exp) * (float64(c) - exp) / exp
+	}
+	df := float64(len(counts) - 1)
+	if lim := df + 5 * math.Sqrt(2 * df); chi2 > lim {
+		t.Errorf("%s: chi2 = %f > %f, df %.0f", name, chi2, lim, df)
+	}
+}
+
+// checkSample fails if dst is not ascending in [0, n).
+func checkSample(t *testing.T, n uint64, dst []uint64) {
+	for i, v := range dst {
+		if v >= n || i > 0 && v <= dst[i - 1] {
+			t.Fatalf("sample of %d not ascending in [0, n): %v", n, dst)
+		}
+	}
+}
+
+// TestSampleSubsets tests that all the subsets of 3 are equally likely
+// for Floyd's algorithm and Algorithms D and A.
+func TestSampleSubsets(t *testing.T) {
+	x := NewXoro(1)
+	for _, c := range []struct {
+		name string
+		n    uint64
+		f    func(*Xoro, uint64, []uint64)
+	}{
+		{"floyd", 12, func(x *Xoro, n uint64, dst []uint64) { x.Sample(n, len(dst), dst) }},
+		{"vitterD", 40, (*Xoro).vitterD},
+		{"vitterD", 60, (*Xoro).vitterD},
+		{"vitterA", 12, func(x *Xoro, n uint64, dst []uint64) { x.vitterA(n, dst, 0) }},
+	} {
+		const k = 3
+		dst := make([]uint64, k)
+		count := map[[k]uint64]int{}
+		subsets := int(c.n * (c.n - 1) * (c.n - 2) / 6)
+		for i := 0; i < 100 * subsets; i++ {
+			c.f(&x, c.n, dst)
+			checkSample(t, c.n, dst)
+			count[[k]uint64(dst)]++
+		}
+		counts := make([]int, 0, subsets)
+		for _, v := range count {
+			counts = append(counts, v)
+		}
+		if len(counts) != subsets {
+			t.Errorf("%s n %d: %d subsets of %d", c.name, c.n, len(counts), subsets)
+		}
+		chiSquareUniform(t, c.name, counts)
+	}
+}
+
+func TestSample(t *testing.T) {
+	r := New(1)
+	for _, c := range []struct {
+		n uint64
+		k int
+	}{
+		{10, 10}, {100, 0}, {1000, 20}, {1000, 100}, {1000, 500}, {1000, 999},
+		{1 << 40, 1000}, {1 << 53, 50}, {1 << 53 + 1, 50}, {math.MaxUint64, 1000},
+	} {
+		dst := make([]uint64, c.k)
+		counts := make([]int, 16)
+		rounds := 20000 / (c.k + 1) + 10
+		for i := 0; i < rounds; i++ {
+			r.Sample(c.n, c.k, dst)
+			checkSample(t, c.n, dst)
+			for _, v := range dst {
+				hi, lo := bits.Mul64(v, 16)
+				q, _ := bits.Div64(hi, lo, c.n)      // v * 16 / n
+				counts[q]++
+			}
+		}
+		if c.k >= 20 && uint64(c.k) < c.n {
+			chiSquareUniform(t, "Sample", counts)
+		}
+	}
+}
+
+func TestSamplePanics(t *testing.T) {
+	for _, f := range []func(){
+		func() { Sample(10, -1, make([]uint64, 1)) },
+		func() { Sample(10, 11, make([]uint64, 11)) },
+		func() { Sample(10, 5, make([]uint64, 4)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
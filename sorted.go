@@ -0,0 +1,91 @@
+package prng
+
+import (
+	"math"
+)
+
+// Sorted uniforms without sorting. The order statistics of n independent
+// uniforms from [0, 1) are U_(k) = 1 - exp(-T_k) with
+// T_k = E_1/n + E_2/(n-1) + ... + E_k/(n-k+1) for independent exponential E_i,
+// Rényi's representation of the exponential order statistics. U_(k) is
+// computed as -expm1(-T_k), which has the full relative precision near 0
+// with the ExpFloat64full variates. The smallest of n uniforms is about 1/n,
+// below the 2^-53 spacing of Float64 for large n. The values are ascending
+// and can be generated one at a time without knowing the later ones.
+//
+// The methods of Xoro in this file are copied to Xosh and MCG by gen.go.
+
+// orderStatistic returns 1 - e^-t in [0, 1).
+func orderStatistic(t float64) float64 {
+	u := -math.Expm1(-t)
+	if u >= 1 {
+		return 0x1.fffffffffffffp-1                   // e^-t < 2^-54
+	}
+	return u
+}
+
+// An Ascending returns the order statistics of n independent uniform
+// float64s from [0, 1) in ascending order, one at a time in constant
+// memory. Next after the n values returns 0, false. The generator is
+// called through the Engine interface.
+type Ascending struct {
+	src Engine
+	n   uint64  // values left
+	t   float64 // -log(1 - U) of the last value
+}
+
+// Next returns the next value and true, or 0 and false if all n values
+// have been returned.
+func (a *Ascending) Next() (float64, bool) {
+	if a.n == 0 {
+		return 0, false
+	}
+	a.t += a.src.ExpFloat64full() / float64(a.n)
+	a.n--
+	return orderStatistic(a.t), true
+}
+
+// Len returns the number of values left.
+func (a *Ascending) Len() uint64 {
+	return a.n
+}
+
+// SortedFloat64s fills dst with len(dst) independent uniformly distributed
+// pseudo-random float64s from [0, 1) in ascending order in O(len(dst)) time.
+func (x *Xoro) SortedFloat64s(dst []float64) {
+	t, n := 0.0, len(dst)
+	for i := range dst {
+		t += x.ExpFloat64full() / float64(n - i)
+		dst[i] = orderStatistic(t)
+	}
+}
+
+// SortedStream returns an Ascending of n uniformly distributed pseudo-random
+// float64s from [0, 1) using the generator x.
+func (x *Xoro) SortedStream(n uint64) *Ascending {
+	return &Ascending{src: x, n: n}
+}
+
+// SortedFloat64s fills dst with len(dst) independent uniformly distributed
+// pseudo-random float64s from [0, 1) in ascending order in O(len(dst)) time.
+func (r *Prng) SortedFloat64s(dst []float64) {
+	r.rng.SortedFloat64s(dst)
+}
+
+// SortedStream returns an Ascending of n uniformly distributed pseudo-random
+// float64s from [0, 1) using the generator of r.
+func (r *Prng) SortedStream(n uint64) *Ascending {
+	return r.rng.SortedStream(n)
+}
+
+// SortedFloat64s fills dst with len(dst) independent uniformly distributed
+// pseudo-random float64s from [0, 1) in ascending order in O(len(dst)) time.
+func SortedFloat64s(dst []float64) {
+	globalPrng.SortedFloat64s(dst)
+}
+
+// SortedStream returns an Ascending of n uniformly distributed pseudo-random
+// float64s from [0, 1) using the system generator.
+func SortedStream(n uint64) *Ascending {
+	return globalPrng.SortedStream(n)
+}
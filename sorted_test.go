@@ -0,0 +1,74 @@
+package prng
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSortedFloat64s(t *testing.T) {
+	const n, reps = 100, 2000
+	x := NewXoro(1)
+	dst := make([]float64, n)
+	all := make([]float64, 0, n * reps)
+	for r := 0; r < reps; r++ {
+		x.SortedFloat64s(dst)
+		for i, v := range dst {
+			if !(0 <= v && v < 1) || i > 0 && v < dst[i - 1] {
+				t.Fatalf("SortedFloat64s: dst[%d] = %g after %g", i, v, dst[max(i - 1, 0)])
+			}
+		}
+		all = append(all, dst...)
+	}
+	// The values of a sorted sample are n independent uniforms.
+	d := ksOneSample(all, func(t float64) float64 { return t })
+	if d > 1.95 / math.Sqrt(n * reps) {
+		t.Errorf("SortedFloat64s KS D = %f", d)
+	}
+}
+
+func TestSortedStream(t *testing.T) {
+	x, y := NewXosh(1), NewXosh(1)
+	dst := make([]float64, 1000)
+	x.SortedFloat64s(dst)
+	a := y.SortedStream(uint64(len(dst)))
+	for i := range dst {
+		if v, ok := a.Next(); !ok || v != dst[i] {
+			t.Fatalf("SortedStream %d: %g, %v, want %g", i, v, ok, dst[i])
+		}
+	}
+	if v, ok := a.Next(); ok || a.Len() != 0 {
+		t.Errorf("SortedStream after n values: %g, %v, Len %d", v, ok, a.Len())
+	}
+}
+
+// TestSortedExtremes tests the smallest and the largest of n uniforms,
+// with the cdfs 1 - (1 - x)^n and x^n, for n far beyond the 2^53 spacing
+// of Float64 near 0.
+func TestSortedExtremes(t *testing.T) {
+	const rounds = 100000
+	r := New(1)
+	for _, n := range []float64{1, 10, 1e6, 1 << 60} {
+		lo, hi := make([]float64, rounds), make([]float64, rounds)
+		for i := range lo {
+			a := r.SortedStream(uint64(n))
+			lo[i], _ = a.Next()
+			hi[i] = lo[i]
+			if n <= 10 {
+				for a.Len() > 0 {
+					hi[i], _ = a.Next()
+				}
+			}
+		}
+		d := ksOneSample(lo, func(x float64) float64 { return -math.Expm1(n * math.Log1p(-x)) })
+		if d > 1.95 / math.Sqrt(rounds) {
+			t.Errorf("n %g smallest KS D = %f", n, d)
+		}
+		if n > 10 {
+			continue
+		}
+		d = ksOneSample(hi, func(x float64) float64 { return math.Pow(x, n) })
+		if d > 1.95 / math.Sqrt(rounds) {
+			t.Errorf("n %g largest KS D = %f", n, d)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package prng
+
+import (
+	"math/rand"
+)
+
+// Source adapters of the generators for math/rand.
+// A source adapter implements math/rand.Source64 and it can be used in
+// rand.New(source) by libraries using a *rand.Rand. The adapter methods
+// are called through the rand.Source64 interface and are not inlined.
+
+var (
+	_ rand.Source64 = (*PrngSource)(nil)
+	_ rand.Source64 = (*XoroSource)(nil)
+	_ rand.Source64 = (*XoshSource)(nil)
+	_ rand.Source64 = (*MCGSource)(nil)
+)
+
+// NewSource returns a new math/rand.Source64 using a Prng seeded with the seed.
+func NewSource(seed int64) rand.Source64 {
+	return NewPrngSource(seed)
+}
+
+// NewMathRand returns a new math/rand.Rand using a Prng seeded with the seed.
+func NewMathRand(seed int64) *rand.Rand {
+	return rand.New(NewPrngSource(seed))
+}
+
+// A PrngSource is a math/rand.Source64 adapter of a Prng.
+type PrngSource struct {
+	rng Prng
+}
+
+// NewPrngSource returns a new PrngSource seeded with the seed.
+func NewPrngSource(seed int64) *PrngSource {
+	s := &PrngSource{}
+	s.Seed(seed)
+	return s
+}
+
+// Seed seeds the source by the seed. Any seed is ok.
+func (s *PrngSource) Seed(seed int64) {
+	s.rng.Seed(uint64(seed))
+}
+
+// Int63 returns a non-negative pseudo-random int64.
+func (s *PrngSource) Int63() int64 {
+	return s.rng.Int63()
+}
+
+// Uint64 returns a pseudo-random uint64.
+func (s *PrngSource) Uint64() uint64 {
+	return s.rng.Uint64()
+}
+
+// A XoroSource is a math/rand.Source64 adapter of a xoroshiro128 generator.
+type XoroSource struct {
+	rng Xoro
+}
+
+// NewXoroSource returns a new XoroSource seeded with the seed.
+func NewXoroSource(seed int64) *XoroSource {
+	s := &XoroSource{}
+	s.Seed(seed)
+	return s
+}
+
+// Seed seeds the source by the seed. Any seed is ok.
+func (s *XoroSource) Seed(seed int64) {
+	s.rng.Seed(uint64(seed))
+}
+
+// Int63 returns a non-negative pseudo-random int64.
+func (s *XoroSource) Int63() int64 {
+	return int64(s.rng.Uint64() >> 1)
+}
+
+// Uint64 returns a pseudo-random uint64.
+func (s *XoroSource) Uint64() uint64 {
+	return s.rng.Uint64()
+}
+
+// A XoshSource is a math/rand.Source64 adapter of a xoshiro256 generator.
+type XoshSource struct {
+	rng Xosh
+}
+
+// NewXoshSource returns a new XoshSource seeded with the seed.
+func NewXoshSource(seed int64) *XoshSource {
+	s := &XoshSource{}
+	s.Seed(seed)
+	return s
+}
+
+// Seed seeds the source by the seed. Any seed is ok.
+func (s *XoshSource) Seed(seed int64) {
+	s.rng.Seed(uint64(seed))
+}
+
+// Int63 returns a non-negative pseudo-random int64.
+func (s *XoshSource) Int63() int64 {
+	return int64(s.rng.Uint64() >> 1)
+}
+
+// Uint64 returns a pseudo-random uint64.
+func (s *XoshSource) Uint64() uint64 {
+	return s.rng.Uint64()
+}
+
+// A MCGSource is a math/rand.Source64 adapter of a MCG generator.
+type MCGSource struct {
+	rng MCG
+}
+
+// NewMCGSource returns a new MCGSource seeded with the seed.
+func NewMCGSource(seed int64) *MCGSource {
+	s := &MCGSource{}
+	s.Seed(seed)
+	return s
+}
+
+// Seed seeds the source by the seed. Any seed is ok.
+func (s *MCGSource) Seed(seed int64) {
+	s.rng.Seed(uint64(seed))
+}
+
+// Int63 returns a non-negative pseudo-random int64.
+func (s *MCGSource) Int63() int64 {
+	return int64(s.rng.Uint64() >> 1)
+}
+
+// Uint64 returns a pseudo-random uint64.
+func (s *MCGSource) Uint64() uint64 {
+	return s.rng.Uint64()
+}
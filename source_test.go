@@ -0,0 +1,62 @@
+package prng
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSourceSeed(t *testing.T) {
+	sources := []rand.Source64{
+		NewPrngSource(1), NewXoroSource(1), NewXoshSource(1), NewMCGSource(1),
+	}
+	for _, s := range sources {
+		r := rand.New(s)
+		a := r.Int63()
+		r.Seed(1)
+		if r.Int63() != a {
+			t.Errorf("%T: Seed does not restart the stream", s)
+		}
+		for i := 0; i < 1000; i++ {
+			if s.Int63() < 0 {
+				t.Fatalf("%T: negative Int63", s)
+			}
+		}
+	}
+	x := NewXosh(1)
+	s := NewXoshSource(1)
+	for i := 0; i < 1000; i++ {
+		u := x.Uint64()
+		if s.Uint64() != u || s.Int63() != int64(x.Uint64()>>1) {
+			t.Fatalf("XoshSource differs from Xosh at %d", i)
+		}
+	}
+}
+
+func TestMathRand(t *testing.T) {
+	const n = 20
+	const rounds = 100000
+	r := NewMathRand(1)
+	var first [n]int
+	for i := 0; i < rounds; i++ {
+		p := r.Perm(n)
+		first[p[0]]++
+		r.Shuffle(n, func(i, j int) { p[i], p[j] = p[j], p[i] })
+	}
+	expected := rounds / n
+	for i, c := range first {
+		if abs(float64(c-expected)/float64(expected)) > 0.05 {
+			t.Errorf("Perm: value %d first %d times, expected %d", i, c, expected)
+		}
+	}
+	sum, sum2 := 0.0, 0.0
+	for i := 0; i < rounds; i++ {
+		z := r.NormFloat64()
+		sum += z
+		sum2 += z * z
+	}
+	mean := sum / rounds
+	variance := sum2/rounds - mean*mean
+	if abs(mean) > 0.02 || abs(variance-1) > 0.02 {
+		t.Errorf("NormFloat64: mean %f variance %f", mean, variance)
+	}
+}
@@ -0,0 +1,153 @@
+package prng
+
+import (
+	"math"
+)
+
+// Uniform points on the unit sphere, in the unit ball and on the standard
+// simplex of any dimension n = len(dst). The 2-D and 3-D sphere and ball
+// use a uniform point (u, v) in the unit disk by rejection from the square:
+// the circle point is (u^2 - v^2, 2uv) / s, s = u^2 + v^2, and the 3-D sphere
+// point is (2u sqrt(1 - s), 2v sqrt(1 - s), 1 - 2s). Marsaglia: Choosing a
+// Point from the Surface of a Sphere, https://doi.org/10.1214/aoms/1177692644.
+// In higher dimensions a sphere point is a vector of n normal variates scaled
+// to unit length and a ball point is a sphere point scaled by U^(1/n). A
+// simplex point is n exponential variates divided by their sum.
+//
+// The methods of Xoro in this file are copied to Xosh and MCG by gen.go.
+
+// OnSphere sets dst to a uniformly distributed pseudo-random point on the unit
+// sphere in len(dst) dimensions. The 2-D and 3-D cases use a point in the unit
+// disk as in Marsaglia's method.
+// It panics if len(dst) == 0.
+func (x *Xoro) OnSphere(dst []float64) {
+	switch len(dst) {
+	case 0:
+		panic("invalid argument to OnSphere")
+	case 1:
+		dst[0] = math.Copysign(1, x.Float64Signed())
+	case 2:
+		u, v, s := x.disk()
+		dst[0], dst[1] = (u * u - v * v) / s, 2 * u * v / s
+	case 3:
+		u, v, s := x.disk()
+		r := 2 * math.Sqrt(1 - s)
+		dst[0], dst[1], dst[2] = r * u, r * v, 1 - 2 * s
+	default:
+		for {
+			s := 0.0
+			for i := range dst {
+				z := x.NormFloat64()
+				dst[i] = z
+				s += z * z
+			}
+			if s > 0 {
+				r := 1 / math.Sqrt(s)
+				for i := range dst {
+					dst[i] *= r
+				}
+				return
+			}
+		}
+	}
+}
+
+// InBall sets dst to a uniformly distributed pseudo-random point in the unit
+// ball in len(dst) dimensions. It panics if len(dst) == 0.
+func (x *Xoro) InBall(dst []float64) {
+	switch len(dst) {
+	case 0:
+		panic("invalid argument to InBall")
+	case 1:
+		dst[0] = x.Float64Signed()
+	case 2:
+		dst[0], dst[1], _ = x.disk()
+	case 3:                                      // acceptance rate π/6
+		for {
+			u, v, w := x.Float64Signed(), x.Float64Signed(), x.Float64Signed()
+			if u * u + v * v + w * w < 1 {
+				dst[0], dst[1], dst[2] = u, v, w
+				return
+			}
+		}
+	default:
+		x.OnSphere(dst)
+		r := math.Exp(-x.ExpFloat64() / float64(len(dst)))  // U^(1/n), U = e^-E
+		for i := range dst {
+			dst[i] *= r
+		}
+	}
+}
+
+// OnSimplex sets dst to a uniformly distributed pseudo-random point on the
+// standard simplex x_i >= 0, sum x_i = 1 in len(dst) dimensions.
+// It panics if len(dst) == 0.
+func (x *Xoro) OnSimplex(dst []float64) {
+	if len(dst) == 0 {
+		panic("invalid argument to OnSimplex")
+	}
+	for {
+		s := 0.0
+		for i := range dst {
+			e := x.ExpFloat64()
+			dst[i] = e
+			s += e
+		}
+		if s > 0 {
+			for i := range dst {
+				dst[i] /= s
+			}
+			return
+		}
+	}
+}
+
+// disk returns a uniform point (u, v) in the unit disk and s = u^2 + v^2,
+// 0 < s < 1. The acceptance rate is π/4.
+func (x *Xoro) disk() (u, v, s float64) {
+	for {
+		u, v = x.Float64Signed(), x.Float64Signed()
+		s = u * u + v * v
+		if s < 1 {
+			return
+		}
+	}
+}
+
+// OnSphere sets dst to a uniformly distributed pseudo-random point on the unit
+// sphere in len(dst) dimensions. It panics if len(dst) == 0.
+func (r *Prng) OnSphere(dst []float64) {
+	r.rng.OnSphere(dst)
+}
+
+// InBall sets dst to a uniformly distributed pseudo-random point in the unit
+// ball in len(dst) dimensions. It panics if len(dst) == 0.
+func (r *Prng) InBall(dst []float64) {
+	r.rng.InBall(dst)
+}
+
+// OnSimplex sets dst to a uniformly distributed pseudo-random point on the
+// standard simplex x_i >= 0, sum x_i = 1 in len(dst) dimensions.
+// It panics if len(dst) == 0.
+func (r *Prng) OnSimplex(dst []float64) {
+	r.rng.OnSimplex(dst)
+}
+
+// OnSphere sets dst to a uniformly distributed pseudo-random point on the unit
+// sphere in len(dst) dimensions. It panics if len(dst) == 0.
+func OnSphere(dst []float64) {
+	globalPrng.OnSphere(dst)
+}
+
+// InBall sets dst to a uniformly distributed pseudo-random point in the unit
+// ball in len(dst) dimensions. It panics if len(dst) == 0.
+func InBall(dst []float64) {
+	globalPrng.InBall(dst)
+}
+
+// OnSimplex sets dst to a uniformly distributed pseudo-random point on the
+// standard simplex x_i >= 0, sum x_i = 1 in len(dst) dimensions.
+// It panics if len(dst) == 0.
+func OnSimplex(dst []float64) {
+	globalPrng.OnSimplex(dst)
+}
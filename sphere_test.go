@@ -0,0 +1,150 @@
+package prng
+
+import (
+	"math"
+	"testing"
+)
+
+// sphereMarginal returns the cdf of a coordinate of a uniform point on the
+// unit sphere in m dimensions, 2 <= m <= 5. The density is proportional to
+// (1 - t^2)^((m-3)/2). A coordinate of a uniform point in the unit ball in n
+// dimensions has the distribution of the sphere in n + 2 dimensions.
+func sphereMarginal(m int, t float64) float64 {
+	switch m {
+	case 2:
+		return 0.5 + math.Asin(t) / math.Pi
+	case 3:
+		return (t + 1) / 2
+	case 4:
+		return 0.5 + (t * math.Sqrt(1 - t * t) + math.Asin(t)) / math.Pi
+	}
+	return (2 + 3 * t - t * t * t) / 4
+}
+
+// projections returns the projections of n points of f on a coordinate axis
+// and on a fixed random direction.
+func projections(f func([]float64), dim, n int) (axis, dir []float64) {
+	r := New(7)
+	a := make([]float64, dim)
+	r.OnSphere(a)
+	p := make([]float64, dim)
+	for i := 0; i < n; i++ {
+		f(p)
+		d := 0.0
+		for j := range p {
+			d += a[j] * p[j]
+		}
+		axis = append(axis, p[dim - 1])
+		dir = append(dir, d)
+	}
+	return
+}
+
+func TestOnSphere(t *testing.T) {
+	const n = 100000
+	x, y, r := NewXoro(1), NewXosh(1), New(1)
+	for _, f := range []func([]float64){x.OnSphere, y.OnSphere, r.OnSphere} {
+		for dim := 2; dim <= 5; dim++ {
+			axis, dir := projections(f, dim, n)
+			for _, a := range [][]float64{axis, dir} {
+				d := ksOneSample(a, func(t float64) float64 { return sphereMarginal(dim, t) })
+				if d > 1.95 / math.Sqrt(n) {
+					t.Errorf("OnSphere dim %d KS D = %f", dim, d)
+				}
+			}
+		}
+	}
+	for _, dim := range []int{1, 2, 3, 4, 50} {
+		p := make([]float64, dim)
+		for i := 0; i < 1000; i++ {
+			r.OnSphere(p)
+			s := 0.0
+			for _, v := range p {
+				s += v * v
+			}
+			if abs(s - 1) > 1e-14 {
+				t.Fatalf("OnSphere dim %d norm^2 = %g", dim, s)
+			}
+		}
+	}
+}
+
+func TestInBall(t *testing.T) {
+	const n = 100000
+	x, y, r := NewXoro(1), NewXosh(1), New(1)
+	for _, f := range []func([]float64){x.InBall, y.InBall, r.InBall} {
+		for dim := 1; dim <= 3; dim++ {
+			axis, dir := projections(f, dim, n)
+			for _, a := range [][]float64{axis, dir} {
+				d := ksOneSample(a, func(t float64) float64 { return sphereMarginal(dim + 2, t) })
+				if d > 1.95 / math.Sqrt(n) {
+					t.Errorf("InBall dim %d KS D = %f", dim, d)
+				}
+			}
+		}
+	}
+	// The radius R of a uniform point in the ball has R^n uniform.
+	for _, dim := range []int{2, 3, 4, 10} {
+		p := make([]float64, dim)
+		a := make([]float64, n)
+		for i := range a {
+			r.InBall(p)
+			s := 0.0
+			for _, v := range p {
+				s += v * v
+			}
+			if s >= 1 {
+				t.Fatalf("InBall dim %d norm^2 = %g", dim, s)
+			}
+			a[i] = math.Pow(s, float64(dim) / 2)
+		}
+		d := ksOneSample(a, func(t float64) float64 { return t })
+		if d > 1.95 / math.Sqrt(n) {
+			t.Errorf("InBall dim %d radius KS D = %f", dim, d)
+		}
+	}
+}
+
+func TestOnSimplex(t *testing.T) {
+	const n = 100000
+	x := NewXosh(1)
+	for _, dim := range []int{1, 2, 3, 7} {
+		p := make([]float64, dim)
+		a := make([]float64, n)
+		for i := range a {
+			x.OnSimplex(p)
+			s := 0.0
+			for _, v := range p {
+				if v < 0 {
+					t.Fatalf("OnSimplex dim %d negative %g", dim, v)
+				}
+				s += v
+			}
+			if abs(s - 1) > 1e-14 {
+				t.Fatalf("OnSimplex dim %d sum = %g", dim, s)
+			}
+			a[i] = p[i % dim]
+		}
+		if dim == 1 {
+			continue
+		}
+		// A coordinate is Beta(1, dim - 1) distributed.
+		d := ksOneSample(a, func(t float64) float64 { return 1 - math.Pow(1 - t, float64(dim - 1)) })
+		if d > 1.95 / math.Sqrt(n) {
+			t.Errorf("OnSimplex dim %d KS D = %f", dim, d)
+		}
+	}
+}
+
+func TestSpherePanics(t *testing.T) {
+	for _, f := range []func([]float64){OnSphere, InBall, OnSimplex} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("no panic for len(dst) == 0")
+				}
+			}()
+			f(nil)
+		}()
+	}
+}
@@ -66,6 +66,34 @@ func (x *Xoro) Uint64() (next uint64) {
 	return
 }
 
+// Uint64n returns an unbiased pseudo-random number in [0,n) as an uint64.
+// It panics if n == 0.
+func (x *Xoro) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64n")
+	}
+	return x.uint64n(n)
+}
+
+// uint64n returns an unbiased pseudo-random number in [0,n) by
+// Lemire's multiply and reject method https://arxiv.org/abs/1805.10941.
+// The high 64 bits of Uint64() * n are in [0,n). A product with
+// the low bits lo < 2^64 % n is rejected and the rest of the products
+// are evenly distributed. Rejecting is needed only if lo < n.
+func (x *Xoro) uint64n(n uint64) uint64 {
+	if n & (n - 1) == 0 {                       // n is power of two
+		return x.Uint64() & (n - 1)
+	}
+	hi, lo := bits.Mul64(x.Uint64(), n)
+	if lo < n {
+		thresh := -n % n                        // 2^64 % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(x.Uint64(), n)
+		}
+	}
+	return hi
+}
+
 func twisted(next uint64) uint64 {
 	shift := (next >> 10) & 63
 	if next % 2 == 0 {
@@ -57,6 +57,30 @@ func (x *Xosh) Uint64() (next uint64) {
 	return
 }
 
+// Uint64n returns an unbiased pseudo-random number in [0,n) as an uint64.
+// It panics if n == 0.
+func (x *Xosh) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64n")
+	}
+	return x.uint64n(n)
+}
+
+// uint64n is Uint64n without the argument check.
+func (x *Xosh) uint64n(n uint64) uint64 {
+	if n & (n - 1) == 0 {
+		return x.Uint64() & (n - 1)
+	}
+	hi, lo := bits.Mul64(x.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(x.Uint64(), n)
+		}
+	}
+	return hi
+}
+
 // Xoshiro256plus is xoshiro256+
 func (x *Xosh) Xoshiro256plus() (next uint64) {
 
@@ -144,6 +168,58 @@ func (x *Xosh) Float64full() float64 {
 	return math.Float64frombits(u >> (exp - 1022) >> 12) // 2^52 subnormal floats
 }
 
+// RandomReal returns a uniformly distributed pseudo-random float64 from [0, 1].
+// The distribution includes all floats, but may miss very few
+// subnormal floats in in [0, 2^-1022).
+// http://prng.di.unimi.it/random_real.c
+func (x *Xosh) RandomReal() float64 {
+
+	u := x.Uint64()
+	z := uint64(bits.LeadingZeros64(u))
+	exp := uint64(64)
+	for u == 0 { 
+		u = x.Uint64() 
+		z = uint64(bits.LeadingZeros64(u))
+		exp += 64
+		if exp + z > 1074 + 64 { return 0 }
+	}
+	u = u << z | x.Uint64() >> (64 - z)
+	return ldexp(float64(u | 1), exp + z)
+}
+
+// Float64Bisect returns a uniformly distributed pseudo-random float64 value in [0, 1).
+// If round is true, rounding is applied and the range is [0, 1].
+// All floats, normal and subnormal, are included.
+func (x *Xosh) Float64Bisect(round bool) float64 {
+
+	left, mean, right := 0.0, 0.5, 1.0
+	for {
+		u := x.Uint64()
+		for b := 0; b < 64; b++ {
+
+			if u & (1<<63) != 0 {
+				left = mean
+			} else {
+				right = mean
+			}
+			u <<= 1
+			mean = (left + right) / 2
+			if mean == left || mean == right {
+				if !round {
+					return left
+				}
+				if b == 63 {
+					u = x.Uint64()
+				}
+				if u & (1<<63) != 0 {
+					return right
+				} 
+				return left
+			}
+		}
+	}
+}
+
 
 // WriteState writes the current state of the generator x to b.
 // WriteState without allocations is faster than State().
@@ -0,0 +1,321 @@
+package prng
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Ziggurat method by Marsaglia & Tsang: The Ziggurat Method for Generating
+// Random Variables, https://www.jstatsoft.org/article/view/v005i08.
+// The area under the density f is covered by 256 horizontal layers of equal
+// area v. Layer 0 is the base strip including the tail beyond r. A single
+// Uint64 gives the layer index from its 8 low bits and the x coordinate from
+// its 53 high bits. Most of the samples are inside the rectangle core of
+// a layer and are accepted by a single integer comparison.
+
+const zigLayers = 256
+
+// A ziggurat holds the layer tables of a decreasing density f.
+type ziggurat struct {
+	k [zigLayers]int64     // k[i] = 2^bits * x[i+1] / x[i]
+	w [zigLayers]float64   // w[i] = x[i] / 2^bits
+	f [zigLayers+1]float64 // f[i] = f(x[i])
+}
+
+// newZiggurat returns the tables for the density f with inverse finv,
+// tail start r and layer area v. bits is the number of bits of the x
+// coordinate integer.
+func newZiggurat(f, finv func(float64) float64, r, v float64, bits uint) *ziggurat {
+	var x [zigLayers + 1]float64
+	x[0] = v / f(r)
+	x[1] = r
+	for i := 2; i < zigLayers; i++ {
+		x[i] = finv(v / x[i-1] + f(x[i-1]))
+	}
+	x[zigLayers] = 0
+	z := &ziggurat{}
+	scale := float64(uint64(1) << bits)
+	for i := 0; i < zigLayers; i++ {
+		z.k[i] = int64(scale * x[i+1] / x[i])
+		z.w[i] = x[i] / scale
+		z.f[i] = f(x[i])
+	}
+	z.f[zigLayers] = f(0)
+	return z
+}
+
+// Normal distribution 256 layer constants from Marsaglia & Tsang.
+const (
+	zigNormR = 3.6541528853610088
+	zigNormV = 0.00492867323399
+)
+
+func normDensity(x float64) float64 {
+	return math.Exp(-0.5 * x * x)
+}
+
+func normDensityInv(y float64) float64 {
+	return math.Sqrt(-2 * math.Log(y))
+}
+
+// zigNorm has the x coordinates as 53-bit signed integers.
+var zigNorm = newZiggurat(normDensity, normDensityInv, zigNormR, zigNormV, 52)
+
+// NormFloat64 returns a standard normal distributed pseudo-random float64
+// by 256 layer ziggurat. The tail beyond 3.654 uses Float64full uniforms
+// and the tail is not truncated by the 2^-53 resolution of Float64.
+func (x *Xoro) NormFloat64() float64 {
+	for {
+		u := x.Uint64()
+		i := u & (zigLayers - 1)
+		j := int64(u) >> 11
+		z := float64(j) * zigNorm.w[i]
+		if j < zigNorm.k[i] && j > -zigNorm.k[i] {  // inside the rectangle core, 98.8%
+			return z
+		}
+		if i == 0 {
+			return x.normTail(j < 0)
+		}
+		if zigNorm.f[i] + x.Float64() * (zigNorm.f[i+1] - zigNorm.f[i]) < normDensity(z) {
+			return z
+		}
+	}
+}
+
+// normTail returns a normal variate from the tail beyond zigNormR by
+// Marsaglia's method. If neg is true, the variate is negative.
+func (x *Xoro) normTail(neg bool) float64 {
+	for {
+		a := -math.Log(x.Float64full()) / zigNormR
+		b := -math.Log(x.Float64full())
+		if b + b > a * a {
+			if neg {
+				return -zigNormR - a
+			}
+			return zigNormR + a
+		}
+	}
+}
+
+// NormFloat64 returns a standard normal distributed pseudo-random float64
+// by 256 layer ziggurat. The tail beyond 3.654 uses Float64full uniforms.
+func (x *Xosh) NormFloat64() float64 {
+	for {
+		u := x.Uint64()
+		i := u & (zigLayers - 1)
+		j := int64(u) >> 11
+		z := float64(j) * zigNorm.w[i]
+		if j < zigNorm.k[i] && j > -zigNorm.k[i] {
+			return z
+		}
+		if i == 0 {
+			return x.normTail(j < 0)
+		}
+		if zigNorm.f[i] + x.Float64() * (zigNorm.f[i+1] - zigNorm.f[i]) < normDensity(z) {
+			return z
+		}
+	}
+}
+
+func (x *Xosh) normTail(neg bool) float64 {
+	for {
+		a := -math.Log(x.Float64full()) / zigNormR
+		b := -math.Log(x.Float64full())
+		if b + b > a * a {
+			if neg {
+				return -zigNormR - a
+			}
+			return zigNormR + a
+		}
+	}
+}
+
+// NormFloat64 returns a standard normal distributed pseudo-random float64
+// by 256 layer ziggurat. The tail beyond 3.654 uses Float64full uniforms.
+func (x *MCG) NormFloat64() float64 {
+	for {
+		u := x.Uint64()
+		i := u & (zigLayers - 1)
+		j := int64(u) >> 11
+		z := float64(j) * zigNorm.w[i]
+		if j < zigNorm.k[i] && j > -zigNorm.k[i] {
+			return z
+		}
+		if i == 0 {
+			return x.normTail(j < 0)
+		}
+		if zigNorm.f[i] + x.Float64() * (zigNorm.f[i+1] - zigNorm.f[i]) < normDensity(z) {
+			return z
+		}
+	}
+}
+
+func (x *MCG) normTail(neg bool) float64 {
+	for {
+		a := -math.Log(x.Float64full()) / zigNormR
+		b := -math.Log(x.Float64full())
+		if b + b > a * a {
+			if neg {
+				return -zigNormR - a
+			}
+			return zigNormR + a
+		}
+	}
+}
+
+// Exponential distribution 256 layer constants from Marsaglia & Tsang.
+const (
+	zigExpR = 7.69711747013104972
+	zigExpV = 0.0039496598225815571993
+)
+
+func expDensityInv(y float64) float64 {
+	return -math.Log(y)
+}
+
+func expDensity(x float64) float64 {
+	return math.Exp(-x)
+}
+
+// zigExp has the x coordinates as 53-bit unsigned integers.
+var zigExp = newZiggurat(expDensity, expDensityInv, zigExpR, zigExpV, 53)
+
+// ExpFloat64 returns an exponentially distributed pseudo-random float64
+// with rate 1 by 256 layer ziggurat. The tail beyond 7.697 is sampled
+// as 7.697 + ExpFloat64 by the memorylessness of the distribution.
+func (x *Xoro) ExpFloat64() float64 {
+	tail := 0.0
+	for {
+		u := x.Uint64()
+		i := u & (zigLayers - 1)
+		j := int64(u >> 11)
+		z := float64(j) * zigExp.w[i]
+		if j < zigExp.k[i] {                             // inside the rectangle core, 98.9%
+			return tail + z
+		}
+		if i == 0 {
+			tail += zigExpR
+			continue
+		}
+		if zigExp.f[i] + x.Float64() * (zigExp.f[i+1] - zigExp.f[i]) < math.Exp(-z) {
+			return tail + z
+		}
+	}
+}
+
+// ExpFloat64Rate returns an exponentially distributed pseudo-random float64
+// with rate lambda by ExpFloat64. It panics if lambda <= 0.
+func (x *Xoro) ExpFloat64Rate(lambda float64) float64 {
+	if lambda <= 0 {
+		panic("invalid argument to ExpFloat64Rate")
+	}
+	return x.ExpFloat64() / lambda
+}
+
+// ExpFloat64full returns an exponentially distributed pseudo-random float64
+// with rate 1. An exponential variate is -ln(U) = K ln2 - ln(M) for a uniform
+// U = 2^-K * M, M in (1/2, 1]. K is the count of leading zeros of a random
+// bit stream as in Float64full, geometric P(K = k) = 2^-(k+1), and
+// -ln(M) = -ln(1 - V) for V uniform in [0, 1/2) by Float64full.
+// The variates are not limited by the float64 range of U and
+// small variates have full precision.
+func (x *Xoro) ExpFloat64full() float64 {
+	k := 0.0
+	u := x.Uint64()
+	for u == 0 {
+		k += 64
+		u = x.Uint64()
+	}
+	k += float64(bits.LeadingZeros64(u))
+	return k * math.Ln2 - math.Log1p(-0.5 * x.Float64full())
+}
+
+// ExpFloat64 returns an exponentially distributed pseudo-random float64
+// with rate 1 by 256 layer ziggurat.
+func (x *Xosh) ExpFloat64() float64 {
+	tail := 0.0
+	for {
+		u := x.Uint64()
+		i := u & (zigLayers - 1)
+		j := int64(u >> 11)
+		z := float64(j) * zigExp.w[i]
+		if j < zigExp.k[i] {
+			return tail + z
+		}
+		if i == 0 {
+			tail += zigExpR
+			continue
+		}
+		if zigExp.f[i] + x.Float64() * (zigExp.f[i+1] - zigExp.f[i]) < math.Exp(-z) {
+			return tail + z
+		}
+	}
+}
+
+// ExpFloat64Rate returns an exponentially distributed pseudo-random float64
+// with rate lambda by ExpFloat64. It panics if lambda <= 0.
+func (x *Xosh) ExpFloat64Rate(lambda float64) float64 {
+	if lambda <= 0 {
+		panic("invalid argument to ExpFloat64Rate")
+	}
+	return x.ExpFloat64() / lambda
+}
+
+// ExpFloat64full returns an exponentially distributed pseudo-random float64
+// with rate 1. The variates are K ln2 - ln(1 - V), where K is the geometric
+// count of leading zeros of a random bit stream and V is uniform in [0, 1/2).
+func (x *Xosh) ExpFloat64full() float64 {
+	k := 0.0
+	u := x.Uint64()
+	for u == 0 {
+		k += 64
+		u = x.Uint64()
+	}
+	k += float64(bits.LeadingZeros64(u))
+	return k * math.Ln2 - math.Log1p(-0.5 * x.Float64full())
+}
+
+// ExpFloat64 returns an exponentially distributed pseudo-random float64
+// with rate 1 by 256 layer ziggurat.
+func (x *MCG) ExpFloat64() float64 {
+	tail := 0.0
+	for {
+		u := x.Uint64()
+		i := u & (zigLayers - 1)
+		j := int64(u >> 11)
+		z := float64(j) * zigExp.w[i]
+		if j < zigExp.k[i] {
+			return tail + z
+		}
+		if i == 0 {
+			tail += zigExpR
+			continue
+		}
+		if zigExp.f[i] + x.Float64() * (zigExp.f[i+1] - zigExp.f[i]) < math.Exp(-z) {
+			return tail + z
+		}
+	}
+}
+
+// ExpFloat64Rate returns an exponentially distributed pseudo-random float64
+// with rate lambda by ExpFloat64. It panics if lambda <= 0.
+func (x *MCG) ExpFloat64Rate(lambda float64) float64 {
+	if lambda <= 0 {
+		panic("invalid argument to ExpFloat64Rate")
+	}
+	return x.ExpFloat64() / lambda
+}
+
+// ExpFloat64full returns an exponentially distributed pseudo-random float64
+// with rate 1. The variates are K ln2 - ln(1 - V), where K is the geometric
+// count of leading zeros of a random bit stream and V is uniform in [0, 1/2).
+func (x *MCG) ExpFloat64full() float64 {
+	k := 0.0
+	u := x.Uint64()
+	for u == 0 {
+		k += 64
+		u = x.Uint64()
+	}
+	k += float64(bits.LeadingZeros64(u))
+	return k * math.Ln2 - math.Log1p(-0.5 * x.Float64full())
+}
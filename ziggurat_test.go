@@ -0,0 +1,147 @@
+package prng
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// ksOneSample returns the one-sample Kolmogorov-Smirnov statistic D of a
+// against the cdf. a is sorted in place.
+func ksOneSample(a []float64, cdf func(float64) float64) float64 {
+	sort.Float64s(a)
+	n := float64(len(a))
+	d := 0.0
+	for i, x := range a {
+		c := cdf(x)
+		d = math.Max(d, math.Max(c-float64(i)/n, float64(i+1)/n-c))
+	}
+	return d
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func TestZigguratTables(t *testing.T) {
+	// The top layer must have the same area v as the others.
+	z := zigNorm
+	x255 := z.w[zigLayers-1] * (1 << 52)
+	v := x255 * (1 - normDensity(x255))
+	t.Logf("x[255] = %e top layer area %e", x255, v)
+	if abs(v-zigNormV)/zigNormV > 1e-6 {
+		t.Errorf("normal ziggurat top layer area %e != %e", v, zigNormV)
+	}
+}
+
+func TestNormFloat64(t *testing.T) {
+	const rounds = 1000000
+	xoro, xosh, mcg, r := NewXoro(1), NewXosh(1), NewMCG(1), New(1)
+	funcs := map[string]func() float64{
+		"Xoro": xoro.NormFloat64,
+		"Xosh": xosh.NormFloat64,
+		"MCG":  mcg.NormFloat64,
+		"Prng": r.NormFloat64,
+	}
+	a := make([]float64, rounds)
+	for name, f := range funcs {
+		for i := range a {
+			a[i] = f()
+		}
+		d := ksOneSample(a, normCDF)
+		t.Logf("%s KS D = %f", name, d)
+		if d > 1.95/math.Sqrt(rounds) {
+			t.Errorf("%s: NormFloat64 KS D = %f", name, d)
+		}
+	}
+}
+
+func TestNormFloat64Tail(t *testing.T) {
+	// The tail beyond zigNormR and the tail beyond 5.
+	const rounds = 100000000
+	x := NewXosh(1)
+	tail, far := 0, 0
+	for i := 0; i < rounds; i++ {
+		z := abs(x.NormFloat64())
+		if z > zigNormR {
+			tail++
+			if z > 5 {
+				far++
+			}
+		}
+	}
+	for _, c := range []struct {
+		lim   float64
+		count int
+	}{{zigNormR, tail}, {5, far}} {
+		expected := rounds * math.Erfc(c.lim/math.Sqrt2)
+		sd := math.Sqrt(expected)
+		t.Logf("|z| > %f: %d expected %f", c.lim, c.count, expected)
+		if abs(float64(c.count)-expected) > 5*sd {
+			t.Errorf("|z| > %f: %d expected %f", c.lim, c.count, expected)
+		}
+	}
+}
+
+func expCDF(x float64) float64 {
+	return -math.Expm1(-x)
+}
+
+func TestExpFloat64(t *testing.T) {
+	const rounds = 1000000
+	xoro, xosh, mcg, r := NewXoro(1), NewXosh(1), NewMCG(1), New(1)
+	funcs := map[string]func() float64{
+		"Xoro":      xoro.ExpFloat64,
+		"Xosh":      xosh.ExpFloat64,
+		"MCG":       mcg.ExpFloat64,
+		"Prng":      r.ExpFloat64,
+		"Xoro full": xoro.ExpFloat64full,
+		"Xosh full": xosh.ExpFloat64full,
+		"MCG full":  mcg.ExpFloat64full,
+		"Rate 2":    func() float64 { return 2 * r.ExpFloat64Rate(2) },
+	}
+	a := make([]float64, rounds)
+	for name, f := range funcs {
+		for i := range a {
+			a[i] = f()
+		}
+		d := ksOneSample(a, expCDF)
+		t.Logf("%-9s KS D = %f", name, d)
+		if d > 1.95/math.Sqrt(rounds) {
+			t.Errorf("%s: ExpFloat64 KS D = %f", name, d)
+		}
+	}
+}
+
+func TestExpFloat64Tail(t *testing.T) {
+	// Counts beyond the ziggurat tail start, beyond 15 and near zero.
+	const rounds = 100000000
+	x := NewXoro(1)
+	lims := []float64{zigExpR, 15}
+	for _, f := range []func() float64{x.ExpFloat64, x.ExpFloat64full} {
+		var count [2]int
+		small := 0
+		for i := 0; i < rounds; i++ {
+			z := f()
+			for j, lim := range lims {
+				if z > lim {
+					count[j]++
+				}
+			}
+			if z < 1e-6 {
+				small++
+			}
+		}
+		for j, lim := range lims {
+			expected := rounds * math.Exp(-lim)
+			t.Logf("z > %f: %d expected %f", lim, count[j], expected)
+			if abs(float64(count[j])-expected) > 5*math.Sqrt(expected)+1 {
+				t.Errorf("z > %f: %d expected %f", lim, count[j], expected)
+			}
+		}
+		expected := rounds * expCDF(1e-6)
+		if abs(float64(small)-expected) > 5*math.Sqrt(expected) {
+			t.Errorf("z < 1e-6: %d expected %f", small, expected)
+		}
+	}
+}